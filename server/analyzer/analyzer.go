@@ -50,6 +50,20 @@ func Analyzer(input string, session *session.Session) (string, error) {
 			return "Partición no creada.", fmt.Errorf(" fdisk: %w", err)
 		}
 
+		if fdisk.Delete != "" {
+			if err = fdisk.Execute(); err != nil {
+				return "Partición no eliminada.", fmt.Errorf(" fdisk: %w", err)
+			}
+			return fmt.Sprintf("Partición eliminada exitosamente:\n - Ruta: %s\n - Nombre: %s\n - Modo: %s", fdisk.Path, fdisk.Name, fdisk.Delete), nil
+		}
+
+		if fdisk.Add != 0 {
+			if err = fdisk.Execute(); err != nil {
+				return "Partición no redimensionada.", fmt.Errorf(" fdisk: %w", err)
+			}
+			return fmt.Sprintf("Partición redimensionada exitosamente:\n - Ruta: %s\n - Nombre: %s\n - Cambio: %d %s", fdisk.Path, fdisk.Name, fdisk.Add, fdisk.Unit), nil
+		}
+
 		if err = fdisk.Execute(); err != nil {
 			return "Partición no creada.", fmt.Errorf(" fdisk: %w", err)
 		}
@@ -67,6 +81,17 @@ func Analyzer(input string, session *session.Session) (string, error) {
 		}
 		return result, nil
 
+	case "unmount":
+		unmount, err := commands.NewUnmount(arguments)
+		if err != nil {
+			return "Partición no desmontada.", fmt.Errorf(" unmount: %w", err)
+		}
+
+		if err = unmount.Execute(); err != nil {
+			return "Partición no desmontada.", fmt.Errorf(" unmount: %w", err)
+		}
+		return fmt.Sprintf("¡Partición %s desmontada exitosamente!", unmount.Id), nil
+
 	case "mounted":
 		result, err := commands.Mounted(arguments)
 		if err != nil {
@@ -85,6 +110,41 @@ func Analyzer(input string, session *session.Session) (string, error) {
 		}
 		return "¡Sistema de archivos formateado exitosamente!", nil
 
+	case "loss":
+		loss, err := commands.NewLoss(arguments)
+		if err != nil {
+			return "Pérdida no simulada.", fmt.Errorf(" loss: %w", err)
+		}
+
+		if err = loss.Execute(session); err != nil {
+			return "Pérdida no simulada.", fmt.Errorf(" loss: %w", err)
+		}
+		return fmt.Sprintf("¡Pérdida simulada en la partición %s!", loss.Id), nil
+
+	case "recovery":
+		recovery, err := commands.NewRecovery(arguments)
+		if err != nil {
+			return "Sistema de archivos no recuperado.", fmt.Errorf(" recovery: %w", err)
+		}
+
+		result, err := recovery.Execute(session)
+		if err != nil {
+			return "Sistema de archivos no recuperado.", fmt.Errorf(" recovery: %w", err)
+		}
+		return result, nil
+
+	case "fsck":
+		fsck, err := commands.NewFsck(arguments)
+		if err != nil {
+			return "Verificación no realizada.", fmt.Errorf(" fsck: %w", err)
+		}
+
+		result, err := fsck.Execute()
+		if err != nil {
+			return "Verificación no realizada.", fmt.Errorf(" fsck: %w", err)
+		}
+		return result, nil
+
 	case "mkfile":
 		mkfile, err := commands.NewMkfile(arguments)
 		if err != nil {
@@ -107,6 +167,98 @@ func Analyzer(input string, session *session.Session) (string, error) {
 		}
 		return "¡Directorio creado exitosamente!", nil
 
+	case "remove":
+		remove, err := commands.NewRemove(arguments)
+		if err != nil {
+			return "Elemento no eliminado.", fmt.Errorf(" remove: %w", err)
+		}
+
+		if err = remove.Execute(session); err != nil {
+			return "Elemento no eliminado.", fmt.Errorf(" remove: %w", err)
+		}
+		return "¡Elemento eliminado exitosamente!", nil
+
+	case "edit":
+		edit, err := commands.NewEdit(arguments)
+		if err != nil {
+			return "Archivo no editado.", fmt.Errorf(" edit: %w", err)
+		}
+
+		if err = edit.Execute(session); err != nil {
+			return "Archivo no editado.", fmt.Errorf(" edit: %w", err)
+		}
+		return "¡Archivo editado exitosamente!", nil
+
+	case "rename":
+		rename, err := commands.NewRename(arguments)
+		if err != nil {
+			return "Elemento no renombrado.", fmt.Errorf(" rename: %w", err)
+		}
+
+		if err = rename.Execute(session); err != nil {
+			return "Elemento no renombrado.", fmt.Errorf(" rename: %w", err)
+		}
+		return "¡Elemento renombrado exitosamente!", nil
+
+	case "move":
+		move, err := commands.NewMove(arguments)
+		if err != nil {
+			return "Elemento no movido.", fmt.Errorf(" move: %w", err)
+		}
+
+		if err = move.Execute(session); err != nil {
+			return "Elemento no movido.", fmt.Errorf(" move: %w", err)
+		}
+		return "¡Elemento movido exitosamente!", nil
+
+	case "copy":
+		cp, err := commands.NewCopy(arguments)
+		if err != nil {
+			return "Elemento no copiado.", fmt.Errorf(" copy: %w", err)
+		}
+
+		result, err := cp.Execute(session)
+		if err != nil {
+			return "Elemento no copiado.", fmt.Errorf(" copy: %w", err)
+		}
+		return result, nil
+
+	case "chmod":
+		chmod, err := commands.NewChmod(arguments)
+		if err != nil {
+			return "Permisos no cambiados.", fmt.Errorf(" chmod: %w", err)
+		}
+
+		result, err := chmod.Execute(session)
+		if err != nil {
+			return "Permisos no cambiados.", fmt.Errorf(" chmod: %w", err)
+		}
+		return result, nil
+
+	case "chown":
+		chown, err := commands.NewChown(arguments)
+		if err != nil {
+			return "Propietario no cambiado.", fmt.Errorf(" chown: %w", err)
+		}
+
+		result, err := chown.Execute(session)
+		if err != nil {
+			return "Propietario no cambiado.", fmt.Errorf(" chown: %w", err)
+		}
+		return result, nil
+
+	case "find":
+		find, err := commands.NewFind(arguments)
+		if err != nil {
+			return "Búsqueda no realizada.", fmt.Errorf(" find: %w", err)
+		}
+
+		result, err := find.Execute(session)
+		if err != nil {
+			return "Búsqueda no realizada.", fmt.Errorf(" find: %w", err)
+		}
+		return result, nil
+
 	case "login":
 		login, err := commands.NewLogin(arguments)
 		if err != nil {
@@ -124,6 +276,20 @@ func Analyzer(input string, session *session.Session) (string, error) {
 		}
 		return "¡Sesión terminada correctamente!", nil
 
+	case "su":
+		su, err := commands.NewSu(arguments)
+		if err != nil {
+			return "Comando no ejecutado.", fmt.Errorf(" su: %w", err)
+		}
+
+		suSession, err := su.Execute(session)
+		if err != nil {
+			return "Comando no ejecutado.", fmt.Errorf(" su: %w", err)
+		}
+
+		// Solo este comando se ejecuta con la identidad del otro usuario
+		return Analyzer(su.Command, suSession)
+
 	case "cat":
 		cat, err := commands.NewCat(arguments)
 		if err != nil {
@@ -180,6 +346,17 @@ func Analyzer(input string, session *session.Session) (string, error) {
 		}
 		return "¡Usuario eliminado exitosamente!", nil
 
+	case "passwd":
+		passwd, err := commands.NewPasswd(arguments)
+		if err != nil {
+			return "Contraseña no cambiada.", fmt.Errorf(" passwd: %w", err)
+		}
+
+		if err = passwd.Execute(session); err != nil {
+			return "Contraseña no cambiada.", fmt.Errorf(" passwd: %w", err)
+		}
+		return "¡Contraseña cambiada exitosamente!", nil
+
 	case "chgrp":
 		chgrp, err := commands.NewChgrp(arguments)
 		if err != nil {
@@ -191,13 +368,35 @@ func Analyzer(input string, session *session.Session) (string, error) {
 		}
 		return "¡Grupo cambiado exitosamente!", nil
 
+	case "addgrp":
+		addgrp, err := commands.NewAddgrp(arguments)
+		if err != nil {
+			return "Usuario no agregado al grupo.", fmt.Errorf(" addgrp: %w", err)
+		}
+
+		if err = addgrp.Execute(session); err != nil {
+			return "Usuario no agregado al grupo.", fmt.Errorf(" addgrp: %w", err)
+		}
+		return "¡Usuario agregado al grupo exitosamente!", nil
+
+	case "delgrp":
+		delgrp, err := commands.NewDelgrp(arguments)
+		if err != nil {
+			return "Usuario no quitado del grupo.", fmt.Errorf(" delgrp: %w", err)
+		}
+
+		if err = delgrp.Execute(session); err != nil {
+			return "Usuario no quitado del grupo.", fmt.Errorf(" delgrp: %w", err)
+		}
+		return "¡Usuario quitado del grupo exitosamente!", nil
+
 	case "rep":
 		rep, err := commands.NewRep(arguments)
 		if err != nil {
 			return "Reporte no creado.", fmt.Errorf(" rep: %w", err)
 		}
 
-		result, err := rep.Execute()
+		result, err := rep.Execute(session)
 		if err != nil {
 			return "Reporte no creado.", fmt.Errorf(" rep: %w", err)
 		}
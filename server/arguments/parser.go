@@ -153,8 +153,8 @@ func ParseFsType(input string) (string, error) {
 	}
 
 	fsType := strings.ToLower(match[1])
-	if fsType != "full" {
-		return "", fmt.Errorf("tipo de formateo inválido: %s (solo se permite full)", fsType)
+	if fsType != "fast" && fsType != "full" {
+		return "", fmt.Errorf("tipo de formateo inválido: %s (solo se permite fast o full)", match[1])
 	}
 
 	return fsType, nil
@@ -287,6 +287,195 @@ func ParseP(input string) (bool, error) {
 	return false, nil
 }
 
+func ParseContenido(input string) (string, error) {
+	re := regexp.MustCompile(`-contenido=(?:"([^"]+)"|([^ ]+))`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return "", fmt.Errorf("no se encontró un contenido válido")
+	}
+
+	if match[1] != "" {
+		return match[1], nil
+	}
+	return match[2], nil
+}
+
+// Texto literal para edit; entre comillas puede llevar espacios
+func ParseTexto(input string) string {
+	re := regexp.MustCompile(`-texto=(?:"([^"]+)"|([^ ]+))`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return ""
+	}
+
+	if match[1] != "" {
+		return match[1]
+	}
+	return match[2]
+}
+
+func ParseDestino(input string) (string, error) {
+	re := regexp.MustCompile(`-destino=(?:"([^"]+)"|([^ ]+))`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return "", fmt.Errorf("no se encontró un destino válido")
+	}
+
+	var destino string
+	if match[1] != "" {
+		destino = match[1]
+	} else {
+		destino = match[2]
+	}
+
+	if !strings.HasPrefix(destino, "/") {
+		return "", fmt.Errorf("la ruta '%s' debe ser absoluta (empezar con /)", destino)
+	}
+
+	return destino, nil
+}
+
+func ParseUgo(input string) ([3]byte, error) {
+	re := regexp.MustCompile(`-ugo=([^ ]+)`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return [3]byte{}, fmt.Errorf("no se encontró un ugo válido")
+	}
+
+	ugo := match[1]
+	if len(ugo) != 3 {
+		return [3]byte{}, fmt.Errorf("permisos inválidos: %s (deben ser tres dígitos entre 0 y 7)", ugo)
+	}
+
+	var perm [3]byte
+	for i := range perm {
+		if ugo[i] < '0' || ugo[i] > '7' {
+			return [3]byte{}, fmt.Errorf("permisos inválidos: %s (deben ser tres dígitos entre 0 y 7)", ugo)
+		}
+		perm[i] = ugo[i]
+	}
+
+	return perm, nil
+}
+
+func ParseUsuario(input string) (string, error) {
+	re := regexp.MustCompile(`-usuario=(?:"([^"]+)"|([^ ]+))`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return "", fmt.Errorf("no se encontró un usuario válido")
+	}
+
+	if match[1] != "" {
+		return match[1], nil
+	}
+	return match[2], nil
+}
+
+func ParseDelete(input string) (string, error) {
+	re := regexp.MustCompile(`-delete=([^ ]+)`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return "", nil
+	}
+
+	mode := strings.ToLower(match[1])
+
+	if mode != "fast" && mode != "full" {
+		return "", fmt.Errorf("modo de eliminación inválido: %s (solo se permite fast o full)", match[1])
+	}
+
+	return mode, nil
+}
+
+func ParseAdd(input string) (int, error) {
+	re := regexp.MustCompile(`-add=([^ ]+)`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return 0, nil
+	}
+
+	add, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, fmt.Errorf("error al convertir el valor de add: %v", err)
+	}
+
+	if add == 0 {
+		return 0, fmt.Errorf("el valor de add debe ser distinto de cero")
+	}
+
+	return add, nil
+}
+
+func ParseFs(input string) (string, error) {
+	re := regexp.MustCompile(`-fs=([^ ]+)`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return "2fs", nil
+	}
+
+	fs := strings.ToLower(match[1])
+	if fs != "2fs" && fs != "3fs" {
+		return "", fmt.Errorf("sistema de archivos inválido: %s (solo se permite 2fs o 3fs)", fs)
+	}
+
+	return fs, nil
+}
+
+func ParseRepair(input string) (bool, error) {
+	reWithValue := regexp.MustCompile(`-repair=`)
+	if reWithValue.MatchString(input) {
+		return false, fmt.Errorf("la bandera -repair no debe llevar valor")
+	}
+
+	reFlag := regexp.MustCompile(`(^|\s)-repair(\s|$)`)
+	if reFlag.MatchString(input) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func ParseCurrent(input string) string {
+	re := regexp.MustCompile(`-current=(?:"([^"]+)"|([^ ]+))`)
+	match := re.FindStringSubmatch(input)
+
+	if match == nil {
+		return ""
+	}
+
+	if match[1] != "" {
+		return match[1]
+	}
+	return match[2]
+}
+
+// Separa los parámetros iniciales (-clave=valor) del resto de la línea, que
+// es otro comando completo con sus propios parámetros.
+func SplitLeadingParams(input string) (string, string) {
+	re := regexp.MustCompile(`^-\w+=(?:"[^"]*"|[^ ]*)\s*`)
+
+	rest := strings.TrimSpace(input)
+	var params []string
+	for {
+		match := re.FindString(rest)
+		if match == "" {
+			break
+		}
+		params = append(params, strings.TrimSpace(match))
+		rest = rest[len(match):]
+	}
+
+	return strings.Join(params, " "), rest
+}
+
 func ValidateParams(input string, allowedParams []string) error {
 	re := regexp.MustCompile(`-([a-zA-Z]\w*)`)
 	matches := re.FindAllStringSubmatch(input, -1)
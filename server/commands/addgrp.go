@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+)
+
+type Addgrp struct {
+	Username  string
+	GroupName string
+}
+
+func NewAddgrp(input string) (*Addgrp, error) {
+	if err := arguments.ValidateParams(input, []string{"user", "grp"}); err != nil {
+		return nil, err
+	}
+
+	username, err := arguments.ParseUser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	groupName, err := arguments.ParseGrp(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Addgrp{
+		Username:  username,
+		GroupName: groupName,
+	}, nil
+}
+
+// Agrega al usuario a un grupo secundario; su grupo principal no cambia.
+// Los permisos de grupo se actualizan en su próximo login.
+func (a *Addgrp) Execute(session *session.Session) error {
+	if !session.IsLoggedIn {
+		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	if session.UserID != structures.RootUID {
+		return fmt.Errorf("permiso denegado: solo root puede agregar usuarios a un grupo")
+	}
+
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
+
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return err
+	}
+
+	if err := users.AddUserToGroup(a.Username, a.GroupName); err != nil {
+		return err
+	}
+
+	if err := users.Save(); err != nil {
+		return err
+	}
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return err
+	}
+
+	// Se registra al final, cuando la operación ya quedó guardada
+	if err := fileSystem.AppendJournal("addgrp", structures.UsersFilePath, fmt.Sprintf("%s,%s", a.Username, a.GroupName), session.UserID, session.GroupID); err != nil {
+		return err
+	}
+
+	return nil
+}
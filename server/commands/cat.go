@@ -44,7 +44,7 @@ func (c *Cat) Execute(session *session.Session) (string, error) {
 
 	var result strings.Builder
 	for _, filePath := range c.Files {
-		fileInode, fileInodeIndex, err := fileSystem.GetInodeByPath(filePath)
+		fileInode, fileInodeIndex, err := fileSystem.ResolvePath(filePath, session.UserID, session.Groups)
 		if err != nil {
 			return "", err
 		}
@@ -53,6 +53,10 @@ func (c *Cat) Execute(session *session.Session) (string, error) {
 			return "", fmt.Errorf("el archivo '%s' no existe", filePath)
 		}
 
+		if err := fileSystem.RequirePermission(fileInode, filePath, session.UserID, session.Groups, structures.PermRead); err != nil {
+			return "", err
+		}
+
 		content, err := fileSystem.ReadFileContent(fileInode)
 		if err != nil {
 			return "", fmt.Errorf("error al leer el contenido del archivo '%s': %w", filePath, err)
@@ -41,6 +41,10 @@ func (c *Chgrp) Execute(session *session.Session) error {
 		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
 	}
 
+	if session.UserID != structures.RootUID {
+		return fmt.Errorf("permiso denegado: solo root puede cambiar el grupo de un usuario")
+	}
+
 	if strings.EqualFold(c.Username, "root") {
 		return fmt.Errorf("no se puede cambiar el grupo del usuario 'root'")
 	}
@@ -56,120 +60,31 @@ func (c *Chgrp) Execute(session *session.Session) error {
 	}
 
 	fileSystem := structures.NewFileSystem(file, superBlock)
-	usersInode, usersInodeIndex, err := fileSystem.GetInodeByPath("/users.txt")
-	if err != nil {
+	if err := fileSystem.CheckJournalSpace(); err != nil {
 		return err
 	}
 
-	if usersInode == nil {
-		return fmt.Errorf("el archivo de usuarios no existe")
-	}
-
-	content, err := fileSystem.ReadFileContent(usersInode)
+	users, err := fileSystem.LoadUsers()
 	if err != nil {
 		return err
 	}
 
-	if !strings.HasSuffix(content, "\n") {
-		content += "\n"
-	}
-
-	if !c.GroupExist(content) {
-		return fmt.Errorf("el grupo '%s' no existe o ya fue eliminado", c.GroupName)
-	}
-
-	newContent, err := c.ChangeGroup(content)
-	if err != nil {
+	if err := users.SetUserGroup(c.Username, c.GroupName); err != nil {
 		return err
 	}
 
-	if err := fileSystem.FreeFileInode(usersInode); err != nil {
+	if err := users.Save(); err != nil {
 		return err
 	}
 
-	AllocatedBlocks, err := fileSystem.AllocateFileBlocks([]byte(newContent))
-	if err != nil {
-		return fmt.Errorf("error al asignar bloques para el nuevo contenido de /users.txt: %v", err)
-	}
-
-	usersInode.Blocks = AllocatedBlocks
-	usersInode.Size = int32(len(newContent))
-	usersInode.UpdateAccessTime()
-	usersInode.UpdateModificationTime()
-
-	offset := int64(fileSystem.Sb.InodeStart + usersInodeIndex*superBlock.InodeSize)
-	if err := utilities.WriteObject(file, *usersInode, offset); err != nil {
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
 		return err
 	}
 
-	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+	// Se registra al final, cuando la operación ya quedó guardada
+	if err := fileSystem.AppendJournal("chgrp", structures.UsersFilePath, fmt.Sprintf("%s,%s", c.Username, c.GroupName), session.UserID, session.GroupID); err != nil {
 		return err
 	}
 
 	return nil
 }
-
-func (c *Chgrp) GroupExist(content string) bool {
-	lines := strings.Split(content, "\n")
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-
-		if trimmedLine == "" {
-			continue
-		}
-
-		fields := strings.Split(trimmedLine, ",")
-
-		if fields[0] == "0" || strings.TrimSpace(fields[1]) != "G" || len(fields) != 3 {
-			continue
-		}
-
-		fileGroupName := strings.TrimSpace(fields[2])
-		if strings.EqualFold(fileGroupName, c.GroupName) {
-			return true
-		}
-	}
-
-	return false
-}
-
-func (c *Chgrp) ChangeGroup(content string) (string, error) {
-	lines := strings.Split(content, "\n")
-	var newContent strings.Builder
-	userFound := false
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		if trimmedLine == "" {
-			continue
-		}
-
-		fields := strings.Split(trimmedLine, ",")
-		if len(fields) < 5 || strings.TrimSpace(fields[1]) != "U" {
-			newContent.WriteString(trimmedLine + "\n")
-			continue
-		}
-
-		id := strings.TrimSpace(fields[0])
-
-		if id == "0" {
-			newContent.WriteString(trimmedLine + "\n")
-			continue
-		}
-
-		username := strings.TrimSpace(fields[3])
-		if strings.EqualFold(username, c.Username) {
-			userFound = true
-			fields[2] = c.GroupName
-		}
-
-		newContent.WriteString(strings.Join(fields, ",") + "\n")
-	}
-
-	if !userFound {
-		return "", fmt.Errorf("el usuario '%s' no existe o ya fue eliminado", c.Username)
-	}
-
-	return newContent.String(), nil
-}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+)
+
+type Chmod struct {
+	Path string
+	Ugo  [3]byte
+	R    bool
+}
+
+func NewChmod(input string) (*Chmod, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "ugo", "r"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ugo, err := arguments.ParseUgo(input)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := arguments.ParseR(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chmod{
+		Path: path,
+		Ugo:  ugo,
+		R:    r,
+	}, nil
+}
+
+func (c *Chmod) Execute(session *session.Session) (string, error) {
+	if !session.IsLoggedIn {
+		return "", fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	cleanPath := path.Clean(c.Path)
+
+	superBlock, file, _, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return "", fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return "", err
+	}
+
+	targetInode, targetInodeIndex, err := fileSystem.ResolvePath(cleanPath, session.UserID, session.Groups)
+	if err != nil {
+		return "", err
+	}
+
+	if session.UserID != structures.RootUID && targetInode.UID != session.UserID {
+		return "", fmt.Errorf("permiso denegado: solo root o el propietario pueden cambiar los permisos de '%s'", cleanPath)
+	}
+
+	changed, err := changeMode(fileSystem, targetInodeIndex, cleanPath, session.UserID, c.Ugo, c.R)
+	if err != nil {
+		return "", err
+	}
+
+	content := string(c.Ugo[:])
+	if c.R {
+		content += ",r"
+	}
+	if err := fileSystem.AppendJournal("chmod", cleanPath, content, session.UserID, session.GroupID); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("¡Permisos cambiados exitosamente!\n - Ruta: %s\n - Permisos: %s\n - Inodos modificados: %d", cleanPath, string(c.Ugo[:]), changed), nil
+}
+
+// Cambia los permisos de los inodos que son del usuario, o de todos si es root.
+// Con recursive también recorre el contenido de las carpetas.
+func changeMode(fileSystem *structures.FileSystem, inodeIndex int32, entryPath string, UID int32, ugo [3]byte, recursive bool) (int, error) {
+	changed := 0
+	err := fileSystem.WalkInodeTree(inodeIndex, entryPath, func(inode *structures.Inode, inodeIndex int32, entryPath string) error {
+		if UID == structures.RootUID || inode.UID == UID {
+			inode.Perm = ugo
+			offset := int64(fileSystem.Sb.InodeStart + inodeIndex*fileSystem.Sb.InodeSize)
+			if err := utilities.WriteObject(fileSystem.File, *inode, offset); err != nil {
+				return err
+			}
+			changed++
+		}
+
+		if !recursive {
+			return structures.ErrSkipFolder
+		}
+		return nil
+	})
+	return changed, err
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+)
+
+type Chown struct {
+	Path    string
+	Usuario string
+	R       bool
+}
+
+func NewChown(input string) (*Chown, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "usuario", "r"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	usuario, err := arguments.ParseUsuario(input)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := arguments.ParseR(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chown{
+		Path:    path,
+		Usuario: usuario,
+		R:       r,
+	}, nil
+}
+
+func (c *Chown) Execute(session *session.Session) (string, error) {
+	if !session.IsLoggedIn {
+		return "", fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	cleanPath := path.Clean(c.Path)
+
+	superBlock, file, _, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return "", fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return "", err
+	}
+
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return "", err
+	}
+
+	newOwner := users.FindUser(c.Usuario)
+	if newOwner == nil {
+		return "", fmt.Errorf("el usuario '%s' no existe o fue eliminado", c.Usuario)
+	}
+	newUID := newOwner.UID
+
+	targetInode, targetInodeIndex, err := fileSystem.ResolvePath(cleanPath, session.UserID, session.Groups)
+	if err != nil {
+		return "", err
+	}
+
+	if session.UserID != structures.RootUID && targetInode.UID != session.UserID {
+		return "", fmt.Errorf("permiso denegado: solo root o el propietario pueden cambiar el propietario de '%s'", cleanPath)
+	}
+
+	changed, err := changeOwner(fileSystem, targetInodeIndex, cleanPath, session.UserID, newUID, c.R)
+	if err != nil {
+		return "", err
+	}
+
+	content := newOwner.Name
+	if c.R {
+		content += ",r"
+	}
+	if err := fileSystem.AppendJournal("chown", cleanPath, content, session.UserID, session.GroupID); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("¡Propietario cambiado exitosamente!\n - Ruta: %s\n - Usuario: %s\n - Inodos modificados: %d", cleanPath, newOwner.Name, changed), nil
+}
+
+// Pasa a newUID los inodos que son del usuario, o todos si es root. Con
+// recursive también recorre el contenido de las carpetas.
+func changeOwner(fileSystem *structures.FileSystem, inodeIndex int32, entryPath string, UID int32, newUID int32, recursive bool) (int, error) {
+	changed := 0
+	err := fileSystem.WalkInodeTree(inodeIndex, entryPath, func(inode *structures.Inode, inodeIndex int32, entryPath string) error {
+		if UID == structures.RootUID || inode.UID == UID {
+			inode.UID = newUID
+			offset := int64(fileSystem.Sb.InodeStart + inodeIndex*fileSystem.Sb.InodeSize)
+			if err := utilities.WriteObject(fileSystem.File, *inode, offset); err != nil {
+				return err
+			}
+			changed++
+		}
+
+		if !recursive {
+			return structures.ErrSkipFolder
+		}
+		return nil
+	})
+	return changed, err
+}
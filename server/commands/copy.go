@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+	"strings"
+)
+
+type Copy struct {
+	Path    string
+	Destino string
+}
+
+func NewCopy(input string) (*Copy, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "destino"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	destino, err := arguments.ParseDestino(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Copy{
+		Path:    path,
+		Destino: destino,
+	}, nil
+}
+
+func (c *Copy) Execute(session *session.Session) (string, error) {
+	if !session.IsLoggedIn {
+		return "", fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	cleanPath := path.Clean(c.Path)
+	cleanDestino := path.Clean(c.Destino)
+
+	if cleanPath == "/" {
+		return "", fmt.Errorf("no se puede copiar la carpeta raíz")
+	}
+
+	if cleanDestino == cleanPath || strings.HasPrefix(cleanDestino, cleanPath+"/") {
+		return "", fmt.Errorf("no se puede copiar '%s' dentro de sí mismo", cleanPath)
+	}
+
+	parentPath := path.Dir(cleanPath)
+	entryName := path.Base(cleanPath)
+
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return "", fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return "", err
+	}
+
+	parentInode, _, err := fileSystem.ResolvePath(parentPath, session.UserID, session.Groups)
+	if err != nil {
+		return "", fmt.Errorf("no se puede copiar: el directorio padre '%s' no existe", parentPath)
+	}
+
+	entryInodeIndex, err := fileSystem.LookupEntry(parentInode, parentPath, entryName, session.UserID, session.Groups)
+	if err != nil {
+		return "", err
+	}
+	if entryInodeIndex == -1 {
+		return "", fmt.Errorf("no se puede copiar '%s': no existe", cleanPath)
+	}
+
+	destInode, destInodeIndex, err := fileSystem.ResolvePath(cleanDestino, session.UserID, session.Groups)
+	if err != nil {
+		return "", fmt.Errorf("no se puede copiar: el destino '%s' no existe", cleanDestino)
+	}
+
+	if destInode.Type != [1]byte{'0'} {
+		return "", fmt.Errorf("el destino '%s' no es una carpeta", cleanDestino)
+	}
+
+	if err := fileSystem.RequirePermission(destInode, cleanDestino, session.UserID, session.Groups, structures.PermWrite); err != nil {
+		return "", err
+	}
+
+	existingInodeIndex, err := fileSystem.LookupEntry(destInode, cleanDestino, entryName, session.UserID, session.Groups)
+	if err != nil {
+		return "", err
+	}
+	if existingInodeIndex != -1 {
+		return "", fmt.Errorf("ya existe un elemento con el nombre '%s' en '%s'", entryName, cleanDestino)
+	}
+
+	freeInodesBefore := superBlock.FreeInodesCount
+	freeBlocksBefore := superBlock.FreeBlocksCount
+
+	var skipped []string
+	copyErr := fileSystem.CopyInodeTree(entryInodeIndex, cleanPath, destInodeIndex, entryName, session.UserID, session.Groups, &skipped)
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return "", err
+	}
+
+	if copyErr != nil {
+		return "", fmt.Errorf("error al copiar '%s': %w", cleanPath, copyErr)
+	}
+
+	if err := fileSystem.AppendJournal("copy", cleanPath, cleanDestino, session.UserID, session.GroupID); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("¡Copia realizada exitosamente!\n")
+	sb.WriteString(fmt.Sprintf(" - Origen: %s\n - Destino: %s\n", cleanPath, path.Join(cleanDestino, entryName)))
+	sb.WriteString(fmt.Sprintf(" - Inodos utilizados: %d\n - Bloques utilizados: %d", freeInodesBefore-superBlock.FreeInodesCount, freeBlocksBefore-superBlock.FreeBlocksCount))
+
+	if len(skipped) > 0 {
+		sb.WriteString("\nOmitidos por falta de permiso de lectura:")
+		for _, skippedPath := range skipped {
+			sb.WriteString(fmt.Sprintf("\n > %s", skippedPath))
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+)
+
+type Delgrp struct {
+	Username  string
+	GroupName string
+}
+
+func NewDelgrp(input string) (*Delgrp, error) {
+	if err := arguments.ValidateParams(input, []string{"user", "grp"}); err != nil {
+		return nil, err
+	}
+
+	username, err := arguments.ParseUser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	groupName, err := arguments.ParseGrp(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Delgrp{
+		Username:  username,
+		GroupName: groupName,
+	}, nil
+}
+
+// Quita al usuario de un grupo secundario. El grupo principal solo se
+// cambia con chgrp.
+func (d *Delgrp) Execute(session *session.Session) error {
+	if !session.IsLoggedIn {
+		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	if session.UserID != structures.RootUID {
+		return fmt.Errorf("permiso denegado: solo root puede quitar usuarios de un grupo")
+	}
+
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
+
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return err
+	}
+
+	if err := users.RemoveUserFromGroup(d.Username, d.GroupName); err != nil {
+		return err
+	}
+
+	if err := users.Save(); err != nil {
+		return err
+	}
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return err
+	}
+
+	// Se registra al final, cuando la operación ya quedó guardada
+	if err := fileSystem.AppendJournal("delgrp", structures.UsersFilePath, fmt.Sprintf("%s,%s", d.Username, d.GroupName), session.UserID, session.GroupID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+)
+
+// El nuevo contenido sale de un archivo del host (-contenido) o se escribe
+// tal cual (-texto)
+type Edit struct {
+	Path      string
+	Contenido string
+	Texto     string
+}
+
+func NewEdit(input string) (*Edit, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "contenido", "texto"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	texto := arguments.ParseTexto(input)
+	contenido, err := arguments.ParseContenido(input)
+	if err != nil && texto == "" {
+		return nil, fmt.Errorf("debe indicar -contenido con un archivo del host o -texto con el nuevo contenido")
+	}
+
+	if contenido != "" && texto != "" {
+		return nil, fmt.Errorf("no se pueden usar -contenido y -texto a la vez")
+	}
+
+	return &Edit{
+		Path:      path,
+		Contenido: contenido,
+		Texto:     texto,
+	}, nil
+}
+
+func (e *Edit) Execute(session *session.Session) error {
+	if !session.IsLoggedIn {
+		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	content := []byte(e.Texto)
+	if e.Contenido != "" {
+		fileContent, err := os.ReadFile(e.Contenido)
+		if err != nil {
+			return fmt.Errorf("error al leer el archivo de contenido '%s': %w", e.Contenido, err)
+		}
+		content = fileContent
+	}
+
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
+
+	fileInode, fileInodeIndex, err := fileSystem.ResolvePath(e.Path, session.UserID, session.Groups)
+	if err != nil {
+		return err
+	}
+
+	if fileInode.Type != [1]byte{'1'} {
+		return fmt.Errorf("la ruta especificada no es un archivo: %s", e.Path)
+	}
+
+	if err := fileSystem.RequirePermission(fileInode, e.Path, session.UserID, session.Groups, structures.PermWrite); err != nil {
+		return err
+	}
+
+	if err := fileSystem.WriteFileContent(fileInode, content); err != nil {
+		return fmt.Errorf("error al escribir el nuevo contenido de '%s': %w", e.Path, err)
+	}
+
+	fileInode.UpdateAccessTime()
+
+	offset := int64(superBlock.InodeStart + fileInodeIndex*superBlock.InodeSize)
+	if err := utilities.WriteObject(file, *fileInode, offset); err != nil {
+		return err
+	}
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return err
+	}
+
+	return fileSystem.AppendJournal("edit", path.Clean(e.Path), string(content), session.UserID, session.GroupID)
+}
@@ -5,20 +5,46 @@ import (
 	"fmt"
 	"os"
 	"server/arguments"
+	"server/stores"
 	"server/structures"
 	"server/utilities"
+	"strings"
 )
 
 type Fdisk struct {
-	Path string
-	Name string
-	Size int
-	Unit string
-	Type string
-	Fit  string
+	Path   string
+	Name   string
+	Size   int
+	Unit   string
+	Type   string
+	Fit    string
+	Delete string
+	Add    int
 }
 
 func NewFdisk(input string) (*Fdisk, error) {
+	deleteMode, err := arguments.ParseDelete(input)
+	if err != nil {
+		return nil, err
+	}
+
+	add, err := arguments.ParseAdd(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleteMode != "" && add != 0 {
+		return nil, fmt.Errorf("no se pueden usar -delete y -add en el mismo comando")
+	}
+
+	if deleteMode != "" {
+		return newFdiskDelete(input, deleteMode)
+	}
+
+	if add != 0 {
+		return newFdiskAdd(input, add)
+	}
+
 	allowed := []string{"size", "unit", "path", "type", "fit", "name"}
 	if err := arguments.ValidateParams(input, allowed); err != nil {
 		return nil, err
@@ -64,6 +90,56 @@ func NewFdisk(input string) (*Fdisk, error) {
 	}, nil
 }
 
+func newFdiskDelete(input string, deleteMode string) (*Fdisk, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "name", "delete"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, true)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := arguments.ParseName(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fdisk{
+		Path:   path,
+		Name:   name,
+		Delete: deleteMode,
+	}, nil
+}
+
+func newFdiskAdd(input string, add int) (*Fdisk, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "name", "add", "unit"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, true)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := arguments.ParseName(input)
+	if err != nil {
+		return nil, err
+	}
+
+	unit, err := arguments.ParseUnit(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fdisk{
+		Path: path,
+		Name: name,
+		Unit: unit,
+		Add:  add,
+	}, nil
+}
+
 func (f *Fdisk) Execute() error {
 	file, err := utilities.OpenFile(f.Path)
 	if err != nil {
@@ -77,6 +153,20 @@ func (f *Fdisk) Execute() error {
 		return fmt.Errorf("error al leer el MBR: %w", err)
 	}
 
+	if f.Delete != "" {
+		if err := f.DeletePartition(file, &mbr); err != nil {
+			return fmt.Errorf("error al eliminar la partición: %w", err)
+		}
+		return nil
+	}
+
+	if f.Add != 0 {
+		if err := f.ResizePartition(file, &mbr); err != nil {
+			return fmt.Errorf("error al redimensionar la partición: %w", err)
+		}
+		return nil
+	}
+
 	switch f.Type {
 	case "P":
 		if err := f.CreatePrimaryPartition(file, &mbr); err != nil {
@@ -123,45 +213,72 @@ func (f *Fdisk) addPartitionToMBR(file *os.File, mbr *structures.MBR) error {
 		return fmt.Errorf("error al escribir el MBR actualizado: %w", err)
 	}
 
+	// Una extendida nueva arranca con un EBR vacío para no heredar datos previos del disco
+	if f.Type == "E" {
+		extendedPartition := mbr.GetPartitionByName(f.Name)
+		emptyEBR := structures.EBR{PartMount: [1]byte{'0'}, PartNext: -1}
+		if err := utilities.WriteObject(file, emptyEBR, int64(extendedPartition.Start)); err != nil {
+			return fmt.Errorf("error al escribir el EBR inicial: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// Ubica la lógica en un espacio libre de la extendida según el ajuste de la extendida
+// y la enlaza en la cadena de EBRs respetando el orden en el disco.
 func (f *Fdisk) CreateLogicalPartition(file *os.File, mbr *structures.MBR) error {
 	extendedPartition := mbr.GetExtendedPartition()
 	if extendedPartition == nil {
 		return fmt.Errorf("aún no existe una partición extendida en este disco")
 	}
 
-	lastEBR, lastEBRposition, err := findLastEBR(file, extendedPartition.Start)
+	ebrs, err := structures.ListEBRs(file, extendedPartition.Start)
 	if err != nil {
-		return fmt.Errorf("error al buscar el último EBR: %w", err)
+		return fmt.Errorf("error al recorrer los EBR: %w", err)
 	}
 
-	var newEBRPosition int32
-	var newPartStart int32
-
-	ebrSize := int32(binary.Size(structures.EBR{}))
-	if lastEBRposition == -1 {
-		newEBRPosition = extendedPartition.Start
-	} else {
-		newEBRPosition = lastEBR.PartStart + lastEBR.PartSize
+	for _, info := range ebrs {
+		if info.EBR.PartSize > 0 && strings.Trim(string(info.EBR.PartName[:]), "\x00 ") == f.Name {
+			return fmt.Errorf("la partición con nombre '%s' ya existe en este disco", f.Name)
+		}
 	}
-	newPartStart = newEBRPosition + ebrSize
 
+	ebrSize := int32(binary.Size(structures.EBR{}))
 	sizeBytes := int32(utilities.ConvertToBytes(f.Size, f.Unit))
-	ebr := structures.NewEBR(f.Fit, newPartStart, sizeBytes, f.Name)
 
-	if newPartStart+sizeBytes > extendedPartition.Start+extendedPartition.Size {
+	spaces := structures.ExtendedFreeSpaces(extendedPartition, ebrs)
+	space, found := structures.ChooseFreeSpace(spaces, ebrSize+sizeBytes, extendedPartition.Fit[0])
+	if !found {
 		return fmt.Errorf("no hay suficiente espacio en la partición extendida para crear la partición lógica '%s'", f.Name)
 	}
 
+	newEBRPosition := space.Start
+	ebr := structures.NewEBR(f.Fit, newEBRPosition+ebrSize, sizeBytes, f.Name)
+
+	// El EBR anterior en el disco pasa a apuntar al nuevo; si el nuevo ocupa el
+	// primer EBR vacío, conserva su enlace al siguiente.
+	previous := -1
+	for i, info := range ebrs {
+		if info.Position < newEBRPosition {
+			previous = i
+		} else if info.Position == newEBRPosition {
+			ebr.PartNext = info.EBR.PartNext
+		}
+	}
+
+	if previous != -1 {
+		ebr.PartNext = ebrs[previous].EBR.PartNext
+	}
+
 	if err := utilities.WriteObject(file, *ebr, int64(newEBRPosition)); err != nil {
 		return fmt.Errorf("error al escribir el nuevo EBR: %w", err)
 	}
 
-	if lastEBRposition != -1 {
-		lastEBR.PartNext = newEBRPosition
-		if err := utilities.WriteObject(file, &lastEBR, int64(lastEBRposition)); err != nil {
+	if previous != -1 {
+		previousEBR := ebrs[previous].EBR
+		previousEBR.PartNext = newEBRPosition
+		if err := utilities.WriteObject(file, previousEBR, int64(ebrs[previous].Position)); err != nil {
 			return fmt.Errorf("error al escribir el EBR anterior: %w", err)
 		}
 	}
@@ -169,28 +286,259 @@ func (f *Fdisk) CreateLogicalPartition(file *os.File, mbr *structures.MBR) error
 	return nil
 }
 
-func findLastEBR(file *os.File, start int32) (structures.EBR, int32, error) {
-	var lastEBR structures.EBR
-	var currentPos int32 = start
-	var lastPos int32 = -1
+func (f *Fdisk) DeletePartition(file *os.File, mbr *structures.MBR) error {
+	if partition := mbr.GetPartitionByName(f.Name); partition != nil {
+		return f.deleteMBRPartition(file, mbr, partition)
+	}
+
+	extendedPartition := mbr.GetExtendedPartition()
+	if extendedPartition == nil {
+		return fmt.Errorf("no se encontró una partición con el nombre '%s'", f.Name)
+	}
+
+	return f.deleteLogicalPartition(file, extendedPartition)
+}
+
+func (f *Fdisk) deleteMBRPartition(file *os.File, mbr *structures.MBR, partition *structures.Partition) error {
+	isExtended := partition.Type == [1]byte{'E'}
+	if err := f.checkNotMounted(isExtended); err != nil {
+		return err
+	}
+
+	if f.Delete == "full" {
+		if err := utilities.WriteZeros(file, int64(partition.Start), int64(partition.Size)); err != nil {
+			return fmt.Errorf("error al limpiar el espacio de la partición: %w", err)
+		}
+	} else if isExtended {
+		// Sin el primer EBR la cadena de lógicas queda descartada
+		if err := utilities.WriteObject(file, structures.EBR{}, int64(partition.Start)); err != nil {
+			return fmt.Errorf("error al limpiar el EBR inicial: %w", err)
+		}
+	}
+
+	*partition = structures.Partition{}
+
+	if err := utilities.WriteObject(file, *mbr, 0); err != nil {
+		return fmt.Errorf("error al escribir el MBR actualizado: %w", err)
+	}
+
+	return nil
+}
+
+func (f *Fdisk) deleteLogicalPartition(file *os.File, extendedPartition *structures.Partition) error {
+	ebr, ebrPosition, err := structures.FindLogicalPartition(file, extendedPartition.Start, f.Name)
+	if err != nil {
+		return err
+	}
+	if ebr == nil {
+		return fmt.Errorf("no se encontró una partición con el nombre '%s'", f.Name)
+	}
+
+	if err := f.checkNotMounted(false); err != nil {
+		return err
+	}
+
+	if ebrPosition == extendedPartition.Start {
+		// El primer EBR ancla la cadena: se vacía pero conserva el enlace al siguiente
+		if f.Delete == "full" {
+			if err := utilities.WriteZeros(file, int64(ebr.PartStart), int64(ebr.PartSize)); err != nil {
+				return fmt.Errorf("error al limpiar el espacio de la partición: %w", err)
+			}
+		}
+
+		emptyEBR := structures.EBR{PartMount: [1]byte{'0'}, PartNext: ebr.PartNext}
+		if err := utilities.WriteObject(file, emptyEBR, int64(ebrPosition)); err != nil {
+			return fmt.Errorf("error al escribir el EBR: %w", err)
+		}
+		return nil
+	}
+
+	previousPosition, err := findPreviousEBR(file, extendedPartition.Start, ebrPosition)
+	if err != nil {
+		return err
+	}
+
+	var previousEBR structures.EBR
+	if err := utilities.ReadObject(file, &previousEBR, int64(previousPosition)); err != nil {
+		return fmt.Errorf("error leyendo EBR en posición %d: %w", previousPosition, err)
+	}
+
+	previousEBR.PartNext = ebr.PartNext
+	if err := utilities.WriteObject(file, previousEBR, int64(previousPosition)); err != nil {
+		return fmt.Errorf("error al escribir el EBR anterior: %w", err)
+	}
+
+	if f.Delete == "full" {
+		if err := utilities.WriteZeros(file, int64(ebrPosition), int64(ebr.PartStart+ebr.PartSize-ebrPosition)); err != nil {
+			return fmt.Errorf("error al limpiar el espacio de la partición: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Rechaza la eliminación si la partición, o alguna lógica cuando es extendida, sigue montada.
+func (f *Fdisk) checkNotMounted(isExtended bool) error {
+	for id, mounted := range stores.MountedPartitions {
+		if mounted.Path != f.Path {
+			continue
+		}
+
+		if strings.Trim(string(mounted.Partition.Name[:]), "\x00 ") == f.Name {
+			return fmt.Errorf("la partición '%s' está montada con el ID %s: desmóntela primero", f.Name, id)
+		}
+
+		if isExtended && mounted.EBRPosition != -1 {
+			return fmt.Errorf("la partición lógica montada con el ID %s pertenece a '%s': desmóntela primero", id, f.Name)
+		}
+	}
+
+	return nil
+}
+
+func findPreviousEBR(file *os.File, start int32, target int32) (int32, error) {
+	currentPos := start
 
 	for {
-		if err := utilities.ReadObject(file, &lastEBR, int64(currentPos)); err != nil {
-			return structures.EBR{}, -1, fmt.Errorf("error leyendo EBR en posición %d: %w", currentPos, err)
+		var ebr structures.EBR
+		if err := utilities.ReadObject(file, &ebr, int64(currentPos)); err != nil {
+			return -1, fmt.Errorf("error leyendo EBR en posición %d: %w", currentPos, err)
 		}
 
-		if lastPos == -1 && lastEBR.PartSize <= 0 {
-			// No hay EBR previos, la partición lógica se creará en el inicio de la extendida
-			break
+		if ebr.PartNext == target {
+			return currentPos, nil
 		}
 
-		lastPos = currentPos
+		if ebr.PartNext <= 0 {
+			return -1, fmt.Errorf("el EBR en posición %d no forma parte de la cadena", target)
+		}
+		currentPos = ebr.PartNext
+	}
+}
+
+func (f *Fdisk) ResizePartition(file *os.File, mbr *structures.MBR) error {
+	delta := int32(utilities.ConvertToBytes(f.Add, f.Unit))
+
+	if partition := mbr.GetPartitionByName(f.Name); partition != nil {
+		minSize, err := f.minimumSize(file, partition.Start, partition.Type == [1]byte{'E'})
+		if err != nil {
+			return err
+		}
 
-		if lastEBR.PartNext < 0 {
-			break
+		newSize, err := f.checkResize(partition.Size, delta, mbr.FreeSpaceAfter(partition), minSize)
+		if err != nil {
+			return err
 		}
-		currentPos = lastEBR.PartNext
+
+		partition.Size = newSize
+		if err := utilities.WriteObject(file, *mbr, 0); err != nil {
+			return fmt.Errorf("error al escribir el MBR actualizado: %w", err)
+		}
+
+		f.updateMountedSize(newSize)
+		return nil
+	}
+
+	extendedPartition := mbr.GetExtendedPartition()
+	if extendedPartition == nil {
+		return fmt.Errorf("no se encontró una partición con el nombre '%s'", f.Name)
+	}
+
+	ebrs, err := structures.ListEBRs(file, extendedPartition.Start)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range ebrs {
+		ebr := info.EBR
+		if ebr.PartSize <= 0 || strings.Trim(string(ebr.PartName[:]), "\x00 ") != f.Name {
+			continue
+		}
+
+		// El espacio libre llega hasta el siguiente EBR o hasta el final de la extendida
+		end := ebr.PartStart + ebr.PartSize
+		nextStart := extendedPartition.Start + extendedPartition.Size
+		for _, other := range ebrs {
+			if other.Position >= end && other.Position < nextStart {
+				nextStart = other.Position
+			}
+		}
+
+		minSize, err := f.minimumSize(file, ebr.PartStart, false)
+		if err != nil {
+			return err
+		}
+
+		newSize, err := f.checkResize(ebr.PartSize, delta, nextStart-end, minSize)
+		if err != nil {
+			return err
+		}
+
+		ebr.PartSize = newSize
+		if err := utilities.WriteObject(file, ebr, int64(info.Position)); err != nil {
+			return fmt.Errorf("error al escribir el EBR actualizado: %w", err)
+		}
+
+		f.updateMountedSize(newSize)
+		return nil
 	}
 
-	return lastEBR, lastPos, nil
+	return fmt.Errorf("no se encontró una partición con el nombre '%s'", f.Name)
+}
+
+func (f *Fdisk) checkResize(size int32, delta int32, freeAfter int32, minSize int32) (int32, error) {
+	if delta > 0 && delta > freeAfter {
+		return 0, fmt.Errorf("no hay suficiente espacio libre después de '%s': disponibles %d bytes, requeridos %d bytes", f.Name, freeAfter, delta)
+	}
+
+	newSize := size + delta
+	if newSize <= 0 {
+		return 0, fmt.Errorf("no se puede reducir '%s' %d bytes: su tamaño actual es %d bytes", f.Name, -delta, size)
+	}
+
+	if newSize < minSize {
+		return 0, fmt.Errorf("no se puede reducir '%s' por debajo de %d bytes sin dañar su contenido", f.Name, minSize)
+	}
+
+	return newSize, nil
+}
+
+// Tamaño mínimo que conserva los EBRs de una extendida o el sistema de archivos formateado.
+func (f *Fdisk) minimumSize(file *os.File, start int32, isExtended bool) (int32, error) {
+	if isExtended {
+		ebrs, err := structures.ListEBRs(file, start)
+		if err != nil {
+			return 0, err
+		}
+
+		var minSize int32
+		ebrSize := int32(binary.Size(structures.EBR{}))
+		for _, info := range ebrs {
+			minSize = max(minSize, info.Position+ebrSize-start)
+			if info.EBR.PartSize > 0 {
+				minSize = max(minSize, info.EBR.PartStart+info.EBR.PartSize-start)
+			}
+		}
+		return minSize, nil
+	}
+
+	var superBlock structures.SuperBlock
+	if err := utilities.ReadObject(file, &superBlock, int64(start)); err != nil {
+		return 0, fmt.Errorf("error al leer el superbloque: %w", err)
+	}
+
+	if superBlock.Magic != 0xEF53 {
+		return 0, nil
+	}
+
+	return superBlock.LayoutEnd() - start, nil
+}
+
+// Mantiene sincronizado el tamaño de la partición si está montada.
+func (f *Fdisk) updateMountedSize(newSize int32) {
+	for _, mounted := range stores.MountedPartitions {
+		if mounted.Path == f.Path && strings.Trim(string(mounted.Partition.Name[:]), "\x00 ") == f.Name {
+			mounted.Partition.Size = newSize
+		}
+	}
 }
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"strings"
+)
+
+type Find struct {
+	Path string
+	Name string
+}
+
+func NewFind(input string) (*Find, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "name"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := arguments.ParseName(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Find{
+		Path: path,
+		Name: strings.Trim(name, "\""),
+	}, nil
+}
+
+func (f *Find) Execute(session *session.Session) (string, error) {
+	if !session.IsLoggedIn {
+		return "", fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	cleanPath := path.Clean(f.Path)
+	pattern := compileFindPattern(f.Name)
+
+	superBlock, file, _, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return "", fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+
+	startInode, startInodeIndex, err := fileSystem.ResolvePath(cleanPath, session.UserID, session.Groups)
+	if err != nil {
+		return "", err
+	}
+
+	if startInode.Type != [1]byte{'0'} {
+		return "", fmt.Errorf("la ruta '%s' no es una carpeta", cleanPath)
+	}
+
+	if err := fileSystem.RequirePermission(startInode, cleanPath, session.UserID, session.Groups, structures.PermRead|structures.PermExecute); err != nil {
+		return "", err
+	}
+
+	var tree strings.Builder
+	printed := make(map[string]bool)
+	matches := 0
+
+	err = fileSystem.WalkInodeTree(startInodeIndex, cleanPath, func(inode *structures.Inode, inodeIndex int32, entryPath string) error {
+		isFolder := inode.Type == [1]byte{'0'}
+
+		if entryPath != cleanPath && pattern.MatchString(path.Base(entryPath)) {
+			matches++
+			relative := strings.TrimPrefix(strings.TrimPrefix(entryPath, cleanPath), "/")
+			parts := strings.Split(relative, "/")
+
+			for i, part := range parts {
+				prefix := strings.Join(parts[:i+1], "/")
+				if printed[prefix] {
+					continue
+				}
+				printed[prefix] = true
+
+				suffix := ""
+				if i < len(parts)-1 || isFolder {
+					suffix = "/"
+				}
+				tree.WriteString(fmt.Sprintf("%s|_ %s%s\n", strings.Repeat("   ", i), part, suffix))
+			}
+		}
+
+		if isFolder && !fileSystem.CheckPermission(inode, session.UserID, session.Groups, structures.PermRead|structures.PermExecute) {
+			return structures.ErrSkipFolder
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if matches == 0 {
+		return fmt.Sprintf("No se encontraron coincidencias para '%s' en '%s'.", f.Name, cleanPath), nil
+	}
+
+	return fmt.Sprintf("Resultados para '%s' en '%s':\n%s\n%s - Coincidencias: %d", f.Name, cleanPath, cleanPath, tree.String(), matches), nil
+}
+
+func compileFindPattern(name string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(name)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("^" + quoted + "$")
+}
@@ -0,0 +1,35 @@
+package commands
+
+import "testing"
+
+func TestCompileFindPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*", "a.txt", true},
+		{"*", "", true},
+		{"*.txt", "notas.txt", true},
+		{"*.txt", "notas.txt.bak", false},
+		{"a?c", "abc", true},
+		{"a?c", "ac", false},
+		{"a?c", "abbc", false},
+		{"?", "ñ", true},
+		{"a*b?", "axxbc", true},
+		{"a*b?", "axxb", false},
+		{"a.b", "a.b", true},
+		{"a.b", "axb", false},
+		{"(x)+", "(x)+", true},
+		{"[ab]", "a", false},
+		{"users.txt", "users.txt", true},
+		{"users.txt", "USERS.txt", false},
+	}
+
+	for _, test := range tests {
+		got := compileFindPattern(test.pattern).MatchString(test.name)
+		if got != test.want {
+			t.Errorf("compileFindPattern(%q).MatchString(%q) = %v, want %v", test.pattern, test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"server/arguments"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+	"strings"
+)
+
+type Fsck struct {
+	Id     string
+	Repair bool
+}
+
+func NewFsck(input string) (*Fsck, error) {
+	if err := arguments.ValidateParams(input, []string{"id", "repair"}); err != nil {
+		return nil, err
+	}
+
+	id, err := arguments.ParseId(input)
+	if err != nil {
+		return nil, fmt.Errorf("error al analizar id: %w", err)
+	}
+
+	repair, err := arguments.ParseRepair(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fsck{
+		Id:     id,
+		Repair: repair,
+	}, nil
+}
+
+func (f *Fsck) Execute() (string, error) {
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(f.Id)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return "", fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", f.Id)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+
+	report, err := fileSystem.CheckConsistency()
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Verificación de la partición %s:\n - Inodos en uso: %d de %d\n - Bloques en uso: %d de %d",
+		f.Id, report.UsedInodes, superBlock.InodesCount, report.UsedBlocks, superBlock.BlocksCount))
+
+	if len(report.Problems) == 0 {
+		result.WriteString("\n¡El sistema de archivos es consistente!")
+		return result.String(), nil
+	}
+
+	result.WriteString(fmt.Sprintf("\nProblemas encontrados (%d):", len(report.Problems)))
+	for _, problem := range report.Problems {
+		result.WriteString("\n - " + problem)
+	}
+
+	if !f.Repair {
+		return result.String(), nil
+	}
+
+	// Solo se reparan los bitmaps y contadores; el resto queda reportado
+	if err := fileSystem.RepairBitmaps(report); err != nil {
+		return "", err
+	}
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return "", err
+	}
+
+	result.WriteString("\n¡Bitmaps y contadores del superbloque reparados!")
+	return result.String(), nil
+}
@@ -7,8 +7,6 @@ import (
 	"server/stores"
 	"server/structures"
 	"server/utilities"
-	"strconv"
-	"strings"
 )
 
 type Login struct {
@@ -54,7 +52,7 @@ func (l *Login) Execute(session *session.Session) error {
 		}
 	}
 
-	superBlock, file, _, err := stores.GetSuperBlock(l.Id)
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(l.Id)
 	if err != nil {
 		return err
 	}
@@ -65,91 +63,38 @@ func (l *Login) Execute(session *session.Session) error {
 	}
 
 	fileSystem := structures.NewFileSystem(file, superBlock)
-	usersInode, usersInodeIndex, err := fileSystem.GetInodeByPath("/users.txt")
+	users, err := fileSystem.LoadUsers()
 	if err != nil {
 		return err
 	}
 
-	if usersInode == nil {
-		return fmt.Errorf("el archivo de usuarios no existe")
-	}
-
-	content, err := fileSystem.ReadFileContent(usersInode)
+	user, group, err := users.Authenticate(l.Username, l.Password)
 	if err != nil {
 		return err
 	}
 
-	usersInode.UpdateAccessTime()
-
-	offset := int64(superBlock.InodeStart + usersInodeIndex*superBlock.InodeSize)
-	if err := utilities.WriteObject(file, *usersInode, offset); err != nil {
-		return err
-	}
-
-	UID, GID, err := l.AuthenticateUser(content)
-	if err != nil {
-		return err
-	}
-
-	session.Login(l.Username, UID, GID, l.Id)
-	fmt.Printf("sesión iniciada correctamente en la partición '%s' para el usuario '%s'\n", l.Id, l.Username)
-	fmt.Printf("UID: %d, GID: %d\n", UID, GID)
-	return nil
-}
-
-func (l *Login) AuthenticateUser(content string) (UID int32, GID int32, err error) {
-	lines := strings.Split(content, "\n")
-	groups := make(map[string]int32)
-	var userUID int32 = -1
-	var userGroupName string
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		if trimmedLine == "" {
-			continue
-		}
-
-		fields := strings.Split(trimmedLine, ",")
-		idStr := strings.TrimSpace(fields[0])
-		lineType := strings.TrimSpace(fields[1])
-
-		if idStr == "0" {
-			continue
+	// Las contraseñas antiguas en texto plano se reemplazan por su hash al entrar
+	if structures.IsLegacyPassword(user.Password) {
+		passwordHash, err := structures.HashPassword(l.Password)
+		if err != nil {
+			return err
 		}
 
-		if lineType == "G" && len(fields) == 3 {
-			groupName := strings.ToLower(strings.TrimSpace(fields[2]))
-			gid, _ := strconv.ParseInt(idStr, 10, 32)
-			groups[groupName] = int32(gid)
+		if err := users.SetUserPassword(user.Name, passwordHash); err != nil {
+			return err
 		}
 
-		if userUID == -1 && lineType == "U" && len(fields) == 5 {
-			fileUsername := strings.TrimSpace(fields[3])
-			if strings.EqualFold(fileUsername, l.Username) {
-				filePassword := strings.TrimSpace(fields[4])
-				if strings.EqualFold(filePassword, l.Password) {
-					uid, _ := strconv.ParseInt(idStr, 10, 32)
-					userUID = int32(uid)
-					userGroupName = strings.ToLower(strings.TrimSpace(fields[2]))
-				}
-			}
+		if err := users.Save(); err != nil {
+			return err
 		}
 
-		if userUID != -1 {
-			if _, ok := groups[userGroupName]; ok {
-				break
-			}
+		if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+			return err
 		}
 	}
 
-	if userUID == -1 {
-		return -1, -1, fmt.Errorf("usuario o contraseña incorrectos")
-	}
-
-	userGID, ok := groups[userGroupName]
-	if !ok {
-		return -1, -1, fmt.Errorf("el grupo '%s' asignado al usuario '%s' no fue encontrado", userGroupName, l.Username)
-	}
-
-	return userUID, userGID, nil
+	session.Login(user.Name, group.GID, user.UID, users.UserGroupIDs(user), l.Id)
+	fmt.Printf("sesión iniciada correctamente en la partición '%s' para el usuario '%s'\n", l.Id, user.Name)
+	fmt.Printf("UID: %d, GID: %d, grupos: %v\n", user.UID, group.GID, session.Groups)
+	return nil
 }
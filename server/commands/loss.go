@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"fmt"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+)
+
+type Loss struct {
+	Id string
+}
+
+func NewLoss(input string) (*Loss, error) {
+	if err := arguments.ValidateParams(input, []string{"id"}); err != nil {
+		return nil, err
+	}
+
+	id, err := arguments.ParseId(input)
+	if err != nil {
+		return nil, fmt.Errorf("error al analizar id: %w", err)
+	}
+
+	return &Loss{
+		Id: id,
+	}, nil
+}
+
+// Simula una falla del disco: se pierden los bitmaps, la tabla de inodos y el
+// área de bloques. El superbloque y el journal se conservan para poder recuperar.
+func (l *Loss) Execute(session *session.Session) error {
+	if !session.IsLoggedIn || session.PartitionID != l.Id {
+		return fmt.Errorf("debe iniciar sesión en la partición '%s' para simular una pérdida", l.Id)
+	}
+
+	if session.UserID != structures.RootUID {
+		return fmt.Errorf("permiso denegado: solo root puede simular una pérdida")
+	}
+
+	superBlock, file, _, err := stores.GetSuperBlock(l.Id)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", l.Id)
+	}
+
+	if !superBlock.IsExt3() {
+		return fmt.Errorf("la partición '%s' no es EXT3: sin journal no se podría recuperar", l.Id)
+	}
+
+	start := int64(superBlock.BmInodeStart)
+	if err := utilities.WriteZeros(file, start, int64(superBlock.LayoutEnd())-start); err != nil {
+		return fmt.Errorf("error al limpiar la partición: %w", err)
+	}
+
+	return nil
+}
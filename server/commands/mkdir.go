@@ -7,6 +7,7 @@ import (
 	"server/session"
 	"server/stores"
 	"server/structures"
+	"server/utilities"
 )
 
 type Mkdir struct {
@@ -60,7 +61,7 @@ func (m *Mkdir) Execute(session *session.Session) error {
 		return fmt.Errorf("el nombre de carpeta '%s' es demasiado largo (máximo 11 caracteres)", folderName)
 	}
 
-	superBlock, file, _, err := stores.GetSuperBlock(session.PartitionID)
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
 	if err != nil {
 		return err
 	}
@@ -71,20 +72,27 @@ func (m *Mkdir) Execute(session *session.Session) error {
 	}
 
 	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
 
 	if m.P {
-		_, _, err := fileSystem.EnsurePathExist(cleanPath, session.UserID, session.GroupID)
+		_, _, err := fileSystem.EnsurePathExist(cleanPath, session.UserID, session.Groups)
 		if err != nil {
 			return fmt.Errorf("error al crear directorios recursivamente: %w", err)
 		}
 	} else {
-		parentInode, parentInodeIndex, err := fileSystem.GetInodeByPath(parentPath)
+		parentInode, parentInodeIndex, err := fileSystem.ResolvePath(parentPath, session.UserID, session.Groups)
 		if err != nil {
 			return err
 		}
 		parentInode.UpdateAccessTime()
 
-		existingInodeIndex, err := fileSystem.GetInodeIndexByName(parentInode, folderName)
+		if err := fileSystem.RequirePermission(parentInode, parentPath, session.UserID, session.Groups, structures.PermWrite); err != nil {
+			return err
+		}
+
+		existingInodeIndex, err := fileSystem.LookupEntry(parentInode, parentPath, folderName, session.UserID, session.Groups)
 		if err != nil {
 			return err
 		}
@@ -103,5 +111,14 @@ func (m *Mkdir) Execute(session *session.Session) error {
 		}
 	}
 
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return err
+	}
+
+	// Se registra al final, cuando la operación ya quedó guardada
+	if err := fileSystem.AppendJournal("mkdir", cleanPath, "", session.UserID, session.GroupID); err != nil {
+		return err
+	}
+
 	return nil
 }
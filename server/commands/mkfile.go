@@ -79,7 +79,7 @@ func (m *Mkfile) Execute(session *session.Session) error {
 		return fmt.Errorf("el nombre de archivo '%s' es demasiado largo (máximo 11 caracteres)", fileName)
 	}
 
-	superBlock, file, _, err := stores.GetSuperBlock(session.PartitionID)
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
 	if err != nil {
 		return err
 	}
@@ -90,25 +90,32 @@ func (m *Mkfile) Execute(session *session.Session) error {
 	}
 
 	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
 
 	var parentInode *structures.Inode
 	var parentInodeIndex int32
 
 	if m.R {
 		var err error
-		parentInode, parentInodeIndex, err = fileSystem.EnsurePathExist(parentPath, session.UserID, session.GroupID)
+		parentInode, parentInodeIndex, err = fileSystem.EnsurePathExist(parentPath, session.UserID, session.Groups)
 		if err != nil {
 			return fmt.Errorf("error creando directorios padres: %w", err)
 		}
 	} else {
 		var err error
-		parentInode, parentInodeIndex, err = fileSystem.GetInodeByPath(parentPath)
+		parentInode, parentInodeIndex, err = fileSystem.ResolvePath(parentPath, session.UserID, session.Groups)
 		if err != nil {
 			return fmt.Errorf("no se puede crear el archivo: el directorio padre '%s' no existe", parentPath)
 		}
 	}
 
-	existingInodeIndex, err := fileSystem.GetInodeIndexByName(parentInode, fileName)
+	if err := fileSystem.RequirePermission(parentInode, parentPath, session.UserID, session.Groups, structures.PermWrite); err != nil {
+		return err
+	}
+
+	existingInodeIndex, err := fileSystem.LookupEntry(parentInode, parentPath, fileName, session.UserID, session.Groups)
 	if err != nil {
 		return err
 	}
@@ -132,30 +139,16 @@ func (m *Mkfile) Execute(session *session.Session) error {
 		contentBytes = []byte(contentBuilder.String())
 	}
 
-	fileInodeIndex, err := superBlock.GetFreeInodeIndex(file)
-	if err != nil {
-		return err
-	}
-
-	AllocatedBlocks, err := fileSystem.AllocateFileBlocks(contentBytes)
-	if err != nil {
-		return fmt.Errorf("error al asignar bloques para el contenido del archivo: %w", err)
-	}
-
-	fileInode := structures.NewInode(session.UserID, session.GroupID, int32(len(contentBytes)), [1]byte{'1'}, [3]byte{'6', '4', '4'})
-	fileInode.Blocks = AllocatedBlocks
-	fileInode.UpdateModificationTime()
-
-	fileNodeOffset := int64(superBlock.InodeStart + fileInodeIndex*superBlock.InodeSize)
-	if err := utilities.WriteObject(file, *fileInode, fileNodeOffset); err != nil {
+	if _, err := fileSystem.CreateFile(parentInode, parentInodeIndex, fileName, contentBytes, session.UserID, session.GroupID); err != nil {
 		return err
 	}
 
-	if err := fileSystem.AddEntryToParent(parentInode, parentInodeIndex, fileName, fileInodeIndex); err != nil {
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
 		return err
 	}
 
-	if err := superBlock.UpdateInodeBitmap(fileInodeIndex, [1]byte{'1'}, file); err != nil {
+	// Se registra al final, cuando la operación ya quedó guardada
+	if err := fileSystem.AppendJournal("mkfile", cleanPath, string(contentBytes), session.UserID, session.GroupID); err != nil {
 		return err
 	}
 
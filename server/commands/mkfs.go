@@ -11,10 +11,11 @@ import (
 type Mkfs struct {
 	Id   string
 	Type string
+	Fs   string
 }
 
 func NewMkfs(input string) (*Mkfs, error) {
-	allowed := []string{"id", "type"}
+	allowed := []string{"id", "type", "fs"}
 	if err := arguments.ValidateParams(input, allowed); err != nil {
 		return nil, err
 	}
@@ -29,9 +30,15 @@ func NewMkfs(input string) (*Mkfs, error) {
 		return nil, fmt.Errorf("error al analizar type: %w", err)
 	}
 
+	fs, err := arguments.ParseFs(input)
+	if err != nil {
+		return nil, fmt.Errorf("error al analizar fs: %w", err)
+	}
+
 	return &Mkfs{
 		Id:   id,
 		Type: fsType,
+		Fs:   fs,
 	}, nil
 }
 
@@ -41,7 +48,12 @@ func (m *Mkfs) Execute() error {
 		return fmt.Errorf("no existe partición montada con ID: %s", m.Id)
 	}
 
-	superBlock := structures.NewSuperBlock(mountedPartition.Partition)
+	filesystemType := int32(2)
+	if m.Fs == "3fs" {
+		filesystemType = 3
+	}
+
+	superBlock := structures.NewSuperBlock(mountedPartition.Partition, filesystemType)
 
 	file, err := utilities.OpenFile(mountedPartition.Path)
 	if err != nil {
@@ -49,6 +61,19 @@ func (m *Mkfs) Execute() error {
 	}
 	defer file.Close()
 
+	// El formateo completo limpia la tabla de inodos y los bloques para que no
+	// queden datos del sistema de archivos anterior; el rápido solo los bitmaps
+	if m.Type == "full" {
+		partitionEnd := int64(mountedPartition.Partition.Start) + int64(mountedPartition.Partition.Size)
+		if err := utilities.WriteZeros(file, int64(superBlock.InodeStart), partitionEnd-int64(superBlock.InodeStart)); err != nil {
+			return fmt.Errorf("error al limpiar inodos y bloques: %v", err)
+		}
+	}
+
+	if err := superBlock.InitializeJournal(file); err != nil {
+		return err
+	}
+
 	if err := superBlock.InitializeBitMaps(file); err != nil {
 		return fmt.Errorf("error al inicializar bitmaps: %v", err)
 	}
@@ -7,8 +7,6 @@ import (
 	"server/stores"
 	"server/structures"
 	"server/utilities"
-	"strconv"
-	"strings"
 )
 
 type Mkusr struct {
@@ -50,6 +48,10 @@ func (m *Mkusr) Execute(session *session.Session) error {
 		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
 	}
 
+	if session.UserID != structures.RootUID {
+		return fmt.Errorf("permiso denegado: solo root puede crear usuarios")
+	}
+
 	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
 	if err != nil {
 		return err
@@ -61,51 +63,25 @@ func (m *Mkusr) Execute(session *session.Session) error {
 	}
 
 	fileSystem := structures.NewFileSystem(file, superBlock)
-	usersInode, usersInodeIndex, err := fileSystem.GetInodeByPath("/users.txt")
-	if err != nil {
+	if err := fileSystem.CheckJournalSpace(); err != nil {
 		return err
 	}
 
-	if usersInode == nil {
-		return fmt.Errorf("el archivo de usuarios no existe")
-	}
-
-	content, err := fileSystem.ReadFileContent(usersInode)
+	users, err := fileSystem.LoadUsers()
 	if err != nil {
 		return err
 	}
 
-	if !strings.HasSuffix(content, "\n") {
-		content += "\n"
-	}
-
-	if !m.GroupExist(content) {
-		return fmt.Errorf("el grupo '%s' no existe", m.GroupName)
-	}
-
-	newUid, err := m.GetNewUID(content)
+	passwordHash, err := structures.HashPassword(m.Password)
 	if err != nil {
 		return err
 	}
 
-	newContent := fmt.Sprintf("%s%d,U,%s,%s,%s\n", content, newUid, m.GroupName, m.Username, m.Password)
-
-	if err := fileSystem.FreeFileInode(usersInode); err != nil {
+	if _, err := users.AddUser(m.Username, m.GroupName, passwordHash); err != nil {
 		return err
 	}
 
-	AllocatedBlocks, err := fileSystem.AllocateFileBlocks([]byte(newContent))
-	if err != nil {
-		return fmt.Errorf("error al asignar bloques para el nuevo contenido de /users.txt: %v", err)
-	}
-
-	usersInode.Blocks = AllocatedBlocks
-	usersInode.Size = int32(len(newContent))
-	usersInode.UpdateAccessTime()
-	usersInode.UpdateModificationTime()
-
-	offset := int64(fileSystem.Sb.InodeStart + usersInodeIndex*superBlock.InodeSize)
-	if err := utilities.WriteObject(file, *usersInode, offset); err != nil {
+	if err := users.Save(); err != nil {
 		return err
 	}
 
@@ -113,64 +89,10 @@ func (m *Mkusr) Execute(session *session.Session) error {
 		return err
 	}
 
-	return nil
-}
-
-func (m *Mkusr) GroupExist(content string) bool {
-	lines := strings.Split(content, "\n")
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-
-		if trimmedLine == "" {
-			continue
-		}
-
-		fields := strings.Split(trimmedLine, ",")
-
-		if fields[0] == "0" || strings.TrimSpace(fields[1]) != "G" || len(fields) != 3 {
-			continue
-		}
-
-		fileGroupName := strings.TrimSpace(fields[2])
-		if strings.EqualFold(fileGroupName, m.GroupName) {
-			return true
-		}
-	}
-
-	return false
-}
-
-func (m *Mkusr) GetNewUID(content string) (int, error) {
-	highestUid := 0
-	lines := strings.Split(content, "\n")
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		if trimmedLine == "" {
-			continue
-		}
-
-		fields := strings.Split(trimmedLine, ",")
-
-		if fields[0] == "0" || strings.TrimSpace(fields[1]) != "U" || len(fields) != 5 {
-			continue
-		}
-
-		fileUsername := strings.TrimSpace(fields[3])
-		if strings.EqualFold(fileUsername, m.Username) {
-			return 0, fmt.Errorf("el usuario '%s' ya existe", fileUsername)
-		}
-
-		uid, err := strconv.Atoi(strings.TrimSpace(fields[0]))
-		if err != nil {
-			return 0, fmt.Errorf("error al analizar el UID: %v", err)
-		}
-
-		if uid > highestUid {
-			highestUid = uid
-		}
+	// Se registra al final, cuando la operación ya quedó guardada
+	if err := fileSystem.AppendJournal("mkusr", structures.UsersFilePath, fmt.Sprintf("%s,%s", m.Username, m.GroupName), session.UserID, session.GroupID); err != nil {
+		return err
 	}
 
-	return highestUid + 1, nil
+	return nil
 }
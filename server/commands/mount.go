@@ -56,6 +56,20 @@ func (m *Mount) Execute() (string, error) {
 
 	partition := mbr.GetPartitionByName(m.Name)
 
+	// Si no está entre las primarias se busca en la cadena de EBRs de la extendida
+	var ebr *structures.EBR
+	ebrPosition := int32(-1)
+	if extendedPartition := mbr.GetExtendedPartition(); partition == nil && extendedPartition != nil {
+		ebr, ebrPosition, err = structures.FindLogicalPartition(file, extendedPartition.Start, m.Name)
+		if err != nil {
+			return "", err
+		}
+
+		if ebr != nil {
+			partition = ebr.ToPartition()
+		}
+	}
+
 	if partition == nil {
 		return "", fmt.Errorf("no se encontró una partición con el nombre '%s'", m.Name)
 	}
@@ -75,15 +89,21 @@ func (m *Mount) Execute() (string, error) {
 	partition.Correlative = int32(partitionCorrelative)
 
 	mountedPartition := &stores.MountedPartition{
-		Path:      m.Path,
-		Partition: partition,
+		Path:        m.Path,
+		Partition:   partition,
+		EBRPosition: ebrPosition,
 	}
 
 	stores.MountedPartitions[partitionId] = mountedPartition
 
 	partition.Status = [1]byte{'1'}
 
-	if err := utilities.WriteObject(file, mbr, 0); err != nil {
+	if ebr != nil {
+		ebr.PartMount = [1]byte{'1'}
+		if err := utilities.WriteObject(file, *ebr, int64(ebrPosition)); err != nil {
+			return "", fmt.Errorf("error al actualizar el EBR: %w", err)
+		}
+	} else if err := utilities.WriteObject(file, mbr, 0); err != nil {
 		return "", fmt.Errorf("error al actualizar el MBR: %w", err)
 	}
 
@@ -99,5 +119,9 @@ func (m *Mount) Execute() (string, error) {
 		}
 	}
 
+	if err := stores.SaveMountTable(); err != nil {
+		return "", err
+	}
+
 	return fmt.Sprintf("¡Partición montada exitosamente!\n - ID: %s\n - Ruta: %s\n - Nombre: %s", partitionId, m.Path, m.Name), nil
 }
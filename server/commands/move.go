@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+	"strings"
+)
+
+type Move struct {
+	Path    string
+	Destino string
+}
+
+func NewMove(input string) (*Move, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "destino"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	destino, err := arguments.ParseDestino(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Move{
+		Path:    path,
+		Destino: destino,
+	}, nil
+}
+
+func (m *Move) Execute(session *session.Session) error {
+	if !session.IsLoggedIn {
+		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	cleanPath := path.Clean(m.Path)
+	cleanDestino := path.Clean(m.Destino)
+
+	if cleanPath == "/" {
+		return fmt.Errorf("no se puede mover la carpeta raíz")
+	}
+
+	if cleanPath == "/users.txt" {
+		return fmt.Errorf("no se puede mover el archivo de usuarios")
+	}
+
+	if cleanDestino == cleanPath || strings.HasPrefix(cleanDestino, cleanPath+"/") {
+		return fmt.Errorf("no se puede mover '%s' dentro de sí mismo", cleanPath)
+	}
+
+	parentPath := path.Dir(cleanPath)
+	entryName := path.Base(cleanPath)
+
+	if parentPath == cleanDestino {
+		return fmt.Errorf("'%s' ya se encuentra en '%s'", entryName, cleanDestino)
+	}
+
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
+
+	parentInode, parentInodeIndex, err := fileSystem.ResolvePath(parentPath, session.UserID, session.Groups)
+	if err != nil {
+		return fmt.Errorf("no se puede mover: el directorio padre '%s' no existe", parentPath)
+	}
+
+	entryInodeIndex, err := fileSystem.LookupEntry(parentInode, parentPath, entryName, session.UserID, session.Groups)
+	if err != nil {
+		return err
+	}
+	if entryInodeIndex == -1 {
+		return fmt.Errorf("no se puede mover '%s': no existe", cleanPath)
+	}
+
+	destInode, destInodeIndex, err := fileSystem.ResolvePath(cleanDestino, session.UserID, session.Groups)
+	if err != nil {
+		return fmt.Errorf("no se puede mover: el destino '%s' no existe", cleanDestino)
+	}
+
+	if destInode.Type != [1]byte{'0'} {
+		return fmt.Errorf("el destino '%s' no es una carpeta", cleanDestino)
+	}
+
+	existingInodeIndex, err := fileSystem.LookupEntry(destInode, cleanDestino, entryName, session.UserID, session.Groups)
+	if err != nil {
+		return err
+	}
+	if existingInodeIndex != -1 {
+		return fmt.Errorf("ya existe un elemento con el nombre '%s' en '%s'", entryName, cleanDestino)
+	}
+
+	var entryInode structures.Inode
+	if err := utilities.ReadObject(file, &entryInode, int64(superBlock.InodeStart+entryInodeIndex*superBlock.InodeSize)); err != nil {
+		return err
+	}
+
+	if err := fileSystem.RequirePermission(&entryInode, cleanPath, session.UserID, session.Groups, structures.PermWrite); err != nil {
+		return err
+	}
+
+	if err := fileSystem.RequirePermission(parentInode, parentPath, session.UserID, session.Groups, structures.PermWrite); err != nil {
+		return err
+	}
+
+	if err := fileSystem.RequirePermission(destInode, cleanDestino, session.UserID, session.Groups, structures.PermWrite); err != nil {
+		return err
+	}
+
+	if err := moveEntry(fileSystem, parentInode, parentInodeIndex, entryName, &entryInode, entryInodeIndex, destInode, destInodeIndex); err != nil {
+		return err
+	}
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return err
+	}
+
+	return fileSystem.AppendJournal("move", cleanPath, cleanDestino, session.UserID, session.GroupID)
+}
+
+// Pasa la entrada de su carpeta a la de destino; una carpeta además actualiza
+// su entrada ".."
+func moveEntry(fileSystem *structures.FileSystem, parentInode *structures.Inode, parentInodeIndex int32, entryName string, entryInode *structures.Inode, entryInodeIndex int32, destInode *structures.Inode, destInodeIndex int32) error {
+	if err := fileSystem.AddEntryToParent(destInode, destInodeIndex, entryName, entryInodeIndex); err != nil {
+		return err
+	}
+
+	if err := fileSystem.RemoveEntryFromParent(parentInode, parentInodeIndex, entryName); err != nil {
+		return err
+	}
+
+	if entryInode.Type == [1]byte{'0'} {
+		return fileSystem.SetFolderParent(entryInodeIndex, destInodeIndex)
+	}
+
+	return nil
+}
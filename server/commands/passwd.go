@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+	"strings"
+)
+
+type Passwd struct {
+	Username string
+	Password string
+	Current  string
+}
+
+func NewPasswd(input string) (*Passwd, error) {
+	allowed := []string{"user", "pass", "current"}
+	if err := arguments.ValidateParams(input, allowed); err != nil {
+		return nil, err
+	}
+
+	username, err := arguments.ParseUser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := arguments.ParsePass(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Passwd{
+		Username: username,
+		Password: password,
+		Current:  arguments.ParseCurrent(input),
+	}, nil
+}
+
+func (p *Passwd) Execute(session *session.Session) error {
+	if !session.IsLoggedIn {
+		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	isRoot := session.UserID == structures.RootUID
+	if !isRoot && !strings.EqualFold(p.Username, session.Username) {
+		return fmt.Errorf("solo root puede cambiar la contraseña de otro usuario")
+	}
+
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
+
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return err
+	}
+
+	user := users.FindUser(p.Username)
+	if user == nil {
+		return fmt.Errorf("el usuario '%s' no existe o ya fue eliminado", p.Username)
+	}
+
+	// Un usuario normal debe confirmar su contraseña actual; root no la necesita
+	if !isRoot {
+		if p.Current == "" {
+			return fmt.Errorf("debe indicar la contraseña actual con -current")
+		}
+
+		if !structures.VerifyPassword(user.Password, p.Current) {
+			return fmt.Errorf("la contraseña actual es incorrecta")
+		}
+	}
+
+	passwordHash, err := structures.HashPassword(p.Password)
+	if err != nil {
+		return err
+	}
+
+	if err := users.SetUserPassword(user.Name, passwordHash); err != nil {
+		return err
+	}
+
+	if err := users.Save(); err != nil {
+		return err
+	}
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return err
+	}
+
+	// Se registra al final, cuando la operación ya quedó guardada
+	if err := fileSystem.AppendJournal("passwd", structures.UsersFilePath, p.Username, session.UserID, session.GroupID); err != nil {
+		return err
+	}
+
+	return nil
+}
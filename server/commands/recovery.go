@@ -0,0 +1,432 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+	"slices"
+	"strings"
+)
+
+type Recovery struct {
+	Id string
+}
+
+func NewRecovery(input string) (*Recovery, error) {
+	if err := arguments.ValidateParams(input, []string{"id"}); err != nil {
+		return nil, err
+	}
+
+	id, err := arguments.ParseId(input)
+	if err != nil {
+		return nil, fmt.Errorf("error al analizar id: %w", err)
+	}
+
+	return &Recovery{
+		Id: id,
+	}, nil
+}
+
+// Reconstruye el sistema de archivos desde el superbloque: se vuelve al estado
+// recién formateado y se reaplican en orden las operaciones del journal.
+func (r *Recovery) Execute(session *session.Session) (string, error) {
+	if !session.IsLoggedIn || session.PartitionID != r.Id {
+		return "", fmt.Errorf("debe iniciar sesión en la partición '%s' para recuperarla", r.Id)
+	}
+
+	// Recovery vuelve a crear /users.txt, así que solo root puede pedirla
+	if session.UserID != structures.RootUID {
+		return "", fmt.Errorf("permiso denegado: solo root puede recuperar el sistema de archivos")
+	}
+
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(r.Id)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return "", fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", r.Id)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+
+	entries, err := fileSystem.ReadJournal()
+	if err != nil {
+		return "", err
+	}
+
+	// El journal no guarda contraseñas. Si /users.txt todavía se puede leer (por
+	// ejemplo, sin una pérdida previa) se toman de ahí.
+	previousUsers, previousErr := fileSystem.LoadUsers()
+
+	superBlock.FreeInodesCount = superBlock.InodesCount
+	superBlock.FreeBlocksCount = superBlock.BlocksCount
+	superBlock.FirstIno = 0
+	superBlock.FirstBlo = 0
+
+	if err := superBlock.InitializeBitMaps(file); err != nil {
+		return "", fmt.Errorf("error al inicializar bitmaps: %v", err)
+	}
+
+	if err := fileSystem.CreateUsersFile(); err != nil {
+		return "", fmt.Errorf("error al crear archivo de usuarios: %v", err)
+	}
+
+	var warnings []string
+	if previousErr != nil {
+		previousUsers = nil
+		warnings = append(warnings, fmt.Sprintf(" - no se pudo leer %s: root queda con la contraseña predeterminada", structures.UsersFilePath))
+	} else if err := restoreRootPassword(fileSystem, previousUsers); err != nil {
+		return "", err
+	}
+
+	// Una entrada que falla no detiene la recuperación, solo se informa
+	var skipped []string
+	for _, entry := range entries {
+		if entry.Content.PathTruncated {
+			skipped = append(skipped, fmt.Sprintf(" - #%d %s %s: la ruta no cupo completa en el journal", entry.Count, entry.GetOperation(), entry.GetPath()))
+			continue
+		}
+
+		if err := replayJournalEntry(fileSystem, previousUsers, &entry); err != nil {
+			skipped = append(skipped, fmt.Sprintf(" - #%d %s %s: %v", entry.Count, entry.GetOperation(), entry.GetPath(), err))
+			continue
+		}
+
+		if entry.Content.ContentTruncated {
+			warnings = append(warnings, fmt.Sprintf(" - #%d %s %s: el contenido se recuperó truncado a %d bytes", entry.Count, entry.GetOperation(), entry.GetPath(), len(entry.GetContent())))
+		}
+	}
+
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return "", err
+	}
+	for _, user := range users.Users() {
+		if user.Password == structures.LockedPassword {
+			warnings = append(warnings, fmt.Sprintf(" - el usuario '%s' quedó bloqueado: root debe asignarle una contraseña con passwd", user.Name))
+		}
+	}
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("¡Sistema de archivos recuperado exitosamente!\n - Entradas aplicadas: %d", len(entries)-len(skipped)))
+	if len(warnings) > 0 {
+		result.WriteString(fmt.Sprintf("\nAdvertencias (%d):\n%s", len(warnings), strings.Join(warnings, "\n")))
+	}
+	if len(skipped) > 0 {
+		result.WriteString(fmt.Sprintf("\nEntradas omitidas (%d):\n%s", len(skipped), strings.Join(skipped, "\n")))
+	}
+
+	return result.String(), nil
+}
+
+// Aplica una entrada del journal a nombre del usuario que la registró, sin
+// revisar permisos porque ya se revisaron al ejecutarla. Las operaciones no se
+// vuelven a registrar en el journal. Copy es la excepción: vuelve a omitir lo
+// que el usuario no podía leer, como lo hizo al ejecutarse.
+func replayJournalEntry(fileSystem *structures.FileSystem, previousUsers *structures.UsersFile, entry *structures.Journal) error {
+	switch entry.GetOperation() {
+	case "mkdir":
+		_, _, err := fileSystem.RestorePath(entry.GetPath(), entry.Content.UID, entry.Content.GID)
+		return err
+
+	case "mkfile":
+		entryPath := entry.GetPath()
+		parentInode, parentInodeIndex, err := fileSystem.RestorePath(path.Dir(entryPath), entry.Content.UID, entry.Content.GID)
+		if err != nil {
+			return err
+		}
+
+		fileName := path.Base(entryPath)
+		existingInodeIndex, err := fileSystem.GetInodeIndexByName(parentInode, fileName)
+		if err != nil {
+			return err
+		}
+		if existingInodeIndex != -1 {
+			return fmt.Errorf("el archivo '%s' ya existe", fileName)
+		}
+
+		_, err = fileSystem.CreateFile(parentInode, parentInodeIndex, fileName, []byte(entry.GetContent()), entry.Content.UID, entry.Content.GID)
+		return err
+
+	case "edit":
+		fileInode, fileInodeIndex, err := fileSystem.GetInodeByPath(entry.GetPath())
+		if err != nil {
+			return err
+		}
+		if fileInode.Type != [1]byte{'1'} {
+			return fmt.Errorf("la ruta no es un archivo")
+		}
+
+		if err := fileSystem.WriteFileContent(fileInode, []byte(entry.GetContent())); err != nil {
+			return err
+		}
+		fileInode.UpdateAccessTime()
+
+		return utilities.WriteObject(fileSystem.File, *fileInode, int64(fileSystem.Sb.InodeStart+fileInodeIndex*fileSystem.Sb.InodeSize))
+
+	case "remove":
+		parentInode, parentInodeIndex, entryInodeIndex, err := findJournalEntry(fileSystem, entry.GetPath())
+		if err != nil {
+			return err
+		}
+
+		if err := fileSystem.FreeInodeTree(entryInodeIndex); err != nil {
+			return err
+		}
+		return fileSystem.RemoveEntryFromParent(parentInode, parentInodeIndex, path.Base(entry.GetPath()))
+
+	case "rename":
+		parentInode, parentInodeIndex, _, err := findJournalEntry(fileSystem, entry.GetPath())
+		if err != nil {
+			return err
+		}
+
+		if err := requireFreeName(fileSystem, parentInode, entry.GetContent()); err != nil {
+			return err
+		}
+		return fileSystem.RenameEntryInParent(parentInode, parentInodeIndex, path.Base(entry.GetPath()), entry.GetContent())
+
+	case "move", "copy":
+		return replayTransfer(fileSystem, entry)
+
+	case "chmod", "chown":
+		return replayOwnership(fileSystem, entry)
+
+	case "mkgrp", "rmgrp", "mkusr", "rmusr", "chgrp", "addgrp", "delgrp", "passwd":
+		users, err := fileSystem.LoadUsers()
+		if err != nil {
+			return err
+		}
+
+		if err := replayUsersEntry(users, previousUsers, entry); err != nil {
+			return err
+		}
+
+		return users.Save()
+
+	default:
+		return fmt.Errorf("operación no soportada")
+	}
+}
+
+// Carpeta padre e inodo de la entrada que registró el journal
+func findJournalEntry(fileSystem *structures.FileSystem, entryPath string) (*structures.Inode, int32, int32, error) {
+	parentInode, parentInodeIndex, err := fileSystem.GetInodeByPath(path.Dir(entryPath))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	entryInodeIndex, err := fileSystem.GetInodeIndexByName(parentInode, path.Base(entryPath))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if entryInodeIndex == -1 {
+		return nil, 0, 0, fmt.Errorf("'%s' no existe", entryPath)
+	}
+
+	return parentInode, parentInodeIndex, entryInodeIndex, nil
+}
+
+func requireFreeName(fileSystem *structures.FileSystem, folder *structures.Inode, name string) error {
+	existingInodeIndex, err := fileSystem.GetInodeIndexByName(folder, name)
+	if err != nil {
+		return err
+	}
+	if existingInodeIndex != -1 {
+		return fmt.Errorf("ya existe un elemento con el nombre '%s'", name)
+	}
+	return nil
+}
+
+// Move y copy guardan la carpeta de destino en el contenido de la entrada
+func replayTransfer(fileSystem *structures.FileSystem, entry *structures.Journal) error {
+	if entry.Content.ContentTruncated {
+		return fmt.Errorf("el destino no cupo completo en el journal")
+	}
+
+	entryPath := entry.GetPath()
+	entryName := path.Base(entryPath)
+
+	parentInode, parentInodeIndex, entryInodeIndex, err := findJournalEntry(fileSystem, entryPath)
+	if err != nil {
+		return err
+	}
+
+	destInode, destInodeIndex, err := fileSystem.GetInodeByPath(entry.GetContent())
+	if err != nil {
+		return err
+	}
+	if destInode.Type != [1]byte{'0'} {
+		return fmt.Errorf("el destino no es una carpeta")
+	}
+
+	if err := requireFreeName(fileSystem, destInode, entryName); err != nil {
+		return err
+	}
+
+	if entry.GetOperation() == "copy" {
+		var skipped []string
+		return fileSystem.CopyInodeTree(entryInodeIndex, entryPath, destInodeIndex, entryName, entry.Content.UID, replayGroupIDs(fileSystem, entry), &skipped)
+	}
+
+	var entryInode structures.Inode
+	if err := utilities.ReadObject(fileSystem.File, &entryInode, int64(fileSystem.Sb.InodeStart+entryInodeIndex*fileSystem.Sb.InodeSize)); err != nil {
+		return err
+	}
+
+	return moveEntry(fileSystem, parentInode, parentInodeIndex, entryName, &entryInode, entryInodeIndex, destInode, destInodeIndex)
+}
+
+// Chmod guarda los permisos y chown el nuevo propietario, seguidos de ",r" si
+// fueron recursivos
+func replayOwnership(fileSystem *structures.FileSystem, entry *structures.Journal) error {
+	if entry.Content.ContentTruncated {
+		return fmt.Errorf("el contenido no cupo completo en el journal")
+	}
+
+	value, flag, _ := strings.Cut(entry.GetContent(), ",")
+	recursive := flag == "r"
+
+	_, inodeIndex, err := fileSystem.GetInodeByPath(entry.GetPath())
+	if err != nil {
+		return err
+	}
+
+	if entry.GetOperation() == "chmod" {
+		if len(value) != 3 {
+			return fmt.Errorf("contenido de la entrada no válido")
+		}
+		_, err := changeMode(fileSystem, inodeIndex, entry.GetPath(), entry.Content.UID, [3]byte([]byte(value)), recursive)
+		return err
+	}
+
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return err
+	}
+
+	newOwner := users.FindUser(value)
+	if newOwner == nil {
+		return fmt.Errorf("el usuario '%s' no existe", value)
+	}
+
+	_, err = changeOwner(fileSystem, inodeIndex, entry.GetPath(), entry.Content.UID, newOwner.UID, recursive)
+	return err
+}
+
+// Grupos del usuario de la entrada, empezando por el que tenía al registrarla
+func replayGroupIDs(fileSystem *structures.FileSystem, entry *structures.Journal) []int32 {
+	gids := []int32{entry.Content.GID}
+
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return gids
+	}
+
+	if user := users.FindUserByUID(entry.Content.UID); user != nil {
+		for _, gid := range users.UserGroupIDs(user) {
+			if !slices.Contains(gids, gid) {
+				gids = append(gids, gid)
+			}
+		}
+	}
+
+	return gids
+}
+
+// Vuelve a poner en el /users.txt recién creado la contraseña que root tenía
+func restoreRootPassword(fileSystem *structures.FileSystem, previousUsers *structures.UsersFile) error {
+	previousRoot := previousUsers.FindUser("root")
+	if previousRoot == nil {
+		return nil
+	}
+
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return err
+	}
+
+	if err := users.SetUserPassword("root", previousRoot.Password); err != nil {
+		return err
+	}
+
+	return users.Save()
+}
+
+// Contraseña que el usuario tenía antes de la recuperación. Si no se conoce la
+// cuenta queda bloqueada hasta que root le asigne otra.
+func previousPassword(previousUsers *structures.UsersFile, name string) string {
+	if previousUsers == nil {
+		return structures.LockedPassword
+	}
+
+	user := previousUsers.FindUser(name)
+	if user == nil {
+		return structures.LockedPassword
+	}
+
+	return user.Password
+}
+
+func replayUsersEntry(users *structures.UsersFile, previousUsers *structures.UsersFile, entry *structures.Journal) error {
+	content := entry.GetContent()
+	fields := strings.Split(content, ",")
+
+	switch entry.GetOperation() {
+	case "mkgrp":
+		_, err := users.AddGroup(content)
+		return err
+
+	case "rmgrp":
+		return users.RemoveGroup(content)
+
+	case "mkusr":
+		if len(fields) != 2 {
+			return fmt.Errorf("contenido de la entrada no válido")
+		}
+		_, err := users.AddUser(fields[0], fields[1], previousPassword(previousUsers, fields[0]))
+		return err
+
+	case "rmusr":
+		return users.RemoveUser(content)
+
+	case "chgrp":
+		if len(fields) != 2 {
+			return fmt.Errorf("contenido de la entrada no válido")
+		}
+		return users.SetUserGroup(fields[0], fields[1])
+
+	case "addgrp":
+		if len(fields) != 2 {
+			return fmt.Errorf("contenido de la entrada no válido")
+		}
+		return users.AddUserToGroup(fields[0], fields[1])
+
+	case "delgrp":
+		if len(fields) != 2 {
+			return fmt.Errorf("contenido de la entrada no válido")
+		}
+		return users.RemoveUserFromGroup(fields[0], fields[1])
+
+	case "passwd":
+		// La contraseña final ya la puso mkusr; root conserva la que tenía o la
+		// predeterminada
+		if users.FindUser(content) == nil {
+			return fmt.Errorf("el usuario '%s' no existe", content)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("operación no soportada")
+	}
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+)
+
+type Remove struct {
+	Path string
+}
+
+func NewRemove(input string) (*Remove, error) {
+	if err := arguments.ValidateParams(input, []string{"path"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Remove{
+		Path: path,
+	}, nil
+}
+
+func (r *Remove) Execute(session *session.Session) error {
+	if !session.IsLoggedIn {
+		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	cleanPath := path.Clean(r.Path)
+
+	if cleanPath == "/" {
+		return fmt.Errorf("no se puede eliminar la carpeta raíz")
+	}
+
+	if cleanPath == "/users.txt" {
+		return fmt.Errorf("no se puede eliminar el archivo de usuarios")
+	}
+
+	parentPath := path.Dir(cleanPath)
+	entryName := path.Base(cleanPath)
+
+	superBlock, file, sbOffset, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
+
+	parentInode, parentInodeIndex, err := fileSystem.ResolvePath(parentPath, session.UserID, session.Groups)
+	if err != nil {
+		return fmt.Errorf("no se puede eliminar: el directorio padre '%s' no existe", parentPath)
+	}
+
+	entryInodeIndex, err := fileSystem.LookupEntry(parentInode, parentPath, entryName, session.UserID, session.Groups)
+	if err != nil {
+		return err
+	}
+	if entryInodeIndex == -1 {
+		return fmt.Errorf("no se puede eliminar '%s': no existe", cleanPath)
+	}
+
+	if err := fileSystem.RequirePermission(parentInode, parentPath, session.UserID, session.Groups, structures.PermWrite); err != nil {
+		return err
+	}
+
+	if err := fileSystem.CheckTreeWritePermission(entryInodeIndex, cleanPath, session.UserID, session.Groups); err != nil {
+		return err
+	}
+
+	if err := fileSystem.FreeInodeTree(entryInodeIndex); err != nil {
+		return fmt.Errorf("error al liberar '%s': %w", cleanPath, err)
+	}
+
+	if err := fileSystem.RemoveEntryFromParent(parentInode, parentInodeIndex, entryName); err != nil {
+		return err
+	}
+
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+		return err
+	}
+
+	return fileSystem.AppendJournal("remove", cleanPath, "", session.UserID, session.GroupID)
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"strings"
+)
+
+type Rename struct {
+	Path string
+	Name string
+}
+
+func NewRename(input string) (*Rename, error) {
+	if err := arguments.ValidateParams(input, []string{"path", "name"}); err != nil {
+		return nil, err
+	}
+
+	path, err := arguments.ParsePath(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := arguments.ParseName(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rename{
+		Path: path,
+		Name: name,
+	}, nil
+}
+
+func (r *Rename) Execute(session *session.Session) error {
+	if !session.IsLoggedIn {
+		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	cleanPath := path.Clean(r.Path)
+
+	if cleanPath == "/" {
+		return fmt.Errorf("no se puede renombrar la carpeta raíz")
+	}
+
+	if cleanPath == "/users.txt" {
+		return fmt.Errorf("no se puede renombrar el archivo de usuarios")
+	}
+
+	if r.Name == "." || r.Name == ".." || strings.Contains(r.Name, "/") {
+		return fmt.Errorf("nombre '%s' no válido", r.Name)
+	}
+
+	if len(r.Name) > 11 {
+		return fmt.Errorf("el nombre '%s' es demasiado largo (máximo 11 caracteres)", r.Name)
+	}
+
+	parentPath := path.Dir(cleanPath)
+	entryName := path.Base(cleanPath)
+
+	superBlock, file, _, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	if err := fileSystem.CheckJournalSpace(); err != nil {
+		return err
+	}
+
+	parentInode, parentInodeIndex, err := fileSystem.ResolvePath(parentPath, session.UserID, session.Groups)
+	if err != nil {
+		return fmt.Errorf("no se puede renombrar: el directorio padre '%s' no existe", parentPath)
+	}
+
+	entryInodeIndex, err := fileSystem.LookupEntry(parentInode, parentPath, entryName, session.UserID, session.Groups)
+	if err != nil {
+		return err
+	}
+	if entryInodeIndex == -1 {
+		return fmt.Errorf("no se puede renombrar '%s': no existe", cleanPath)
+	}
+
+	existingInodeIndex, err := fileSystem.GetInodeIndexByName(parentInode, r.Name)
+	if err != nil {
+		return err
+	}
+	if existingInodeIndex != -1 {
+		return fmt.Errorf("ya existe un elemento con el nombre '%s' en '%s'", r.Name, parentPath)
+	}
+
+	if err := fileSystem.RequirePermission(parentInode, parentPath, session.UserID, session.Groups, structures.PermWrite); err != nil {
+		return err
+	}
+
+	if err := fileSystem.RenameEntryInParent(parentInode, parentInodeIndex, entryName, r.Name); err != nil {
+		return err
+	}
+
+	return fileSystem.AppendJournal("rename", cleanPath, r.Name, session.UserID, session.GroupID)
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"server/arguments"
+	"server/session"
 	"server/stores"
 	"server/structures"
 	"server/utilities"
@@ -53,7 +54,7 @@ func NewRep(input string) (*Rep, error) {
 	}, nil
 }
 
-func (r *Rep) Execute() (string, error) {
+func (r *Rep) Execute(session *session.Session) (string, error) {
 	switch r.Name {
 	case "mbr":
 		dotCode, err := r.generateMBRReport()
@@ -130,14 +131,14 @@ func (r *Rep) Execute() (string, error) {
 		return fmt.Sprintf("¡Reporte %s generado exitosamente!", r.Name), nil
 
 	case "file":
-		if err := r.generateFileReport(); err != nil {
+		if err := r.generateFileReport(session); err != nil {
 			return "", fmt.Errorf("error al generar reporte de archivo: %w", err)
 		}
 
 		return fmt.Sprintf("¡Archivo extraído exitosamente a %s!", r.Path), nil
 
 	case "ls":
-		dotCode, err := r.generateLsReport()
+		dotCode, err := r.generateLsReport(session)
 		if err != nil {
 			return "", fmt.Errorf("error al generar reporte ls: %w", err)
 		}
@@ -158,6 +159,17 @@ func (r *Rep) Execute() (string, error) {
 		}
 		return "¡Reporte tree generado exitosamente!", nil
 
+	case "journaling":
+		dotCode, err := r.generateJournalingReport(session)
+		if err != nil {
+			return "", fmt.Errorf("error al generar reporte de journaling: %w", err)
+		}
+
+		if err := r.generateImage(dotCode); err != nil {
+			return "", fmt.Errorf("error al generar imagen: %w", err)
+		}
+		return "¡Reporte de journaling generado exitosamente!", nil
+
 	default:
 		return "", fmt.Errorf("tipo de reporte no reconocido: %s", r.Name)
 	}
@@ -284,6 +296,67 @@ func (r *Rep) generateBlocksReport() (string, error) {
 	processedBlocks := make(map[int32]bool) // Para no dibujar el mismo bloque dos veces
 	var lastBlockIndex int32 = -1           // Para enlazar los nodos en orden de descubrimiento
 
+	// Añade la tabla del bloque y la enlaza con el bloque anterior
+	appendBlock := func(blockIndex int32, tableCode string) {
+		if tableCode == "" {
+			return
+		}
+
+		sb.WriteString(tableCode)
+
+		if lastBlockIndex != -1 {
+			sb.WriteString(fmt.Sprintf("block%d -> block%d;", lastBlockIndex, blockIndex))
+		}
+		lastBlockIndex = blockIndex
+		processedBlocks[blockIndex] = true
+	}
+
+	// Lee un bloque de datos y llama al GenerateTable correcto según el tipo del inodo
+	dataBlockTable := func(blockIndex int32, inodeType byte) string {
+		blockOffset := int64(superBlock.BlockStart + blockIndex*superBlock.BlockSize)
+
+		switch inodeType {
+		case '0': // Carpeta
+			var folderBlock structures.FolderBlock
+			if err := utilities.ReadObject(file, &folderBlock, blockOffset); err == nil {
+				return folderBlock.GenerateTable(blockIndex)
+			}
+		case '1': // Archivo
+			var fileBlock structures.FileBlock
+			if err := utilities.ReadObject(file, &fileBlock, blockOffset); err == nil {
+				return fileBlock.GenerateTable(blockIndex)
+			}
+		}
+		return ""
+	}
+
+	// Dibuja un bloque de punteros y desciende hasta los bloques de datos
+	var appendPointerBlock func(blockIndex int32, level int, inodeType byte)
+	appendPointerBlock = func(blockIndex int32, level int, inodeType byte) {
+		if blockIndex < 0 || blockIndex >= superBlock.BlocksCount || processedBlocks[blockIndex] {
+			return
+		}
+
+		var pointerBlock structures.PointerBlock
+		blockOffset := int64(superBlock.BlockStart + blockIndex*superBlock.BlockSize)
+		if err := utilities.ReadObject(file, &pointerBlock, blockOffset); err != nil {
+			return
+		}
+		appendBlock(blockIndex, pointerBlock.GenerateTable(blockIndex))
+
+		for _, ptr := range pointerBlock.Pointers {
+			if ptr == -1 {
+				continue
+			}
+
+			if level > 1 {
+				appendPointerBlock(ptr, level-1, inodeType)
+			} else if ptr >= 0 && ptr < superBlock.BlocksCount && !processedBlocks[ptr] {
+				appendBlock(ptr, dataBlockTable(ptr, inodeType))
+			}
+		}
+	}
+
 	// Recorremos los inodos para obtener el contexto
 	for i, bit := range inodeBitmap {
 		if bit != '1' {
@@ -302,39 +375,10 @@ func (r *Rep) generateBlocksReport() (string, error) {
 				continue // Omitir punteros vacíos o bloques ya procesados
 			}
 
-			// Leemos el bloque y determinamos su tipo para llamar al GenerateTable correcto
-			var tableCode string
-			blockOffset := int64(superBlock.BlockStart + blockIndex*superBlock.BlockSize)
-
 			if k >= 12 { // Es un bloque de punteros (indirecto simple en adelante)
-				var pointerBlock structures.PointerBlock
-				if err := utilities.ReadObject(file, &pointerBlock, blockOffset); err == nil {
-					tableCode = pointerBlock.GenerateTable(blockIndex)
-				}
+				appendPointerBlock(blockIndex, k-11, inode.Type[0])
 			} else { // Es un bloque de datos (archivo o carpeta)
-				switch inode.Type[0] {
-				case '0': // Carpeta
-					var folderBlock structures.FolderBlock
-					if err := utilities.ReadObject(file, &folderBlock, blockOffset); err == nil {
-						tableCode = folderBlock.GenerateTable(blockIndex)
-					}
-				case '1': // Archivo
-					var fileBlock structures.FileBlock
-					if err := utilities.ReadObject(file, &fileBlock, blockOffset); err == nil {
-						tableCode = fileBlock.GenerateTable(blockIndex)
-					}
-				}
-			}
-
-			// Si se generó la tabla, la añadimos y enlazamos
-			if tableCode != "" {
-				sb.WriteString(tableCode)
-
-				if lastBlockIndex != -1 {
-					sb.WriteString(fmt.Sprintf("block%d -> block%d;", lastBlockIndex, blockIndex))
-				}
-				lastBlockIndex = blockIndex
-				processedBlocks[blockIndex] = true
+				appendBlock(blockIndex, dataBlockTable(blockIndex, inode.Type[0]))
 			}
 		}
 	}
@@ -391,11 +435,15 @@ func (r *Rep) generateBlockBitmapReport() (string, error) {
 	return sb.String(), nil
 }
 
-func (r *Rep) generateFileReport() error {
+func (r *Rep) generateFileReport(session *session.Session) error {
 	if r.PathFileLs == "" {
 		return fmt.Errorf("la ruta del archivo a extraer (-ruta) no está especificada")
 	}
 
+	if err := r.requireSession(session); err != nil {
+		return err
+	}
+
 	superBlock, file, _, err := stores.GetSuperBlock(r.Id)
 	if err != nil {
 		return err
@@ -404,13 +452,17 @@ func (r *Rep) generateFileReport() error {
 
 	fileSystem := structures.NewFileSystem(file, superBlock)
 
-	fileInode, fileInodeIndex, err := fileSystem.GetInodeByPath(r.PathFileLs)
+	fileInode, fileInodeIndex, err := fileSystem.ResolvePath(r.PathFileLs, session.UserID, session.Groups)
 	if err != nil {
 		return err
 	}
 	if fileInode.Type[0] != '1' {
 		return fmt.Errorf("la ruta especificada no es un archivo: %s", r.PathFileLs)
 	}
+
+	if err := fileSystem.RequirePermission(fileInode, r.PathFileLs, session.UserID, session.Groups, structures.PermRead); err != nil {
+		return err
+	}
 	content, err := fileSystem.ReadFileContent(fileInode)
 	if err != nil {
 		return fmt.Errorf("error al leer el contenido del archivo: %w", err)
@@ -430,11 +482,15 @@ func (r *Rep) generateFileReport() error {
 	return utilities.WriteObject(file, *fileInode, offset)
 }
 
-func (r *Rep) generateLsReport() (string, error) {
+func (r *Rep) generateLsReport(session *session.Session) (string, error) {
 	if r.PathFileLs == "" {
 		return "", fmt.Errorf("la ruta del archivo para realizar el reporte ls no está especificada")
 	}
 
+	if err := r.requireSession(session); err != nil {
+		return "", err
+	}
+
 	superBlock, file, _, err := stores.GetSuperBlock(r.Id)
 	if err != nil {
 		return "", err
@@ -443,6 +499,15 @@ func (r *Rep) generateLsReport() (string, error) {
 
 	fileSystem := structures.NewFileSystem(file, superBlock)
 
+	lsInode, _, err := fileSystem.ResolvePath(r.PathFileLs, session.UserID, session.Groups)
+	if err != nil {
+		return "", err
+	}
+
+	if err := fileSystem.RequirePermission(lsInode, r.PathFileLs, session.UserID, session.Groups, structures.PermRead); err != nil {
+		return "", err
+	}
+
 	dotCode, err := fileSystem.GenerateLsDOT(r.PathFileLs)
 	if err != nil {
 		return "", fmt.Errorf("error al generar el código DOT: %w", err)
@@ -468,6 +533,44 @@ func (r *Rep) generateTreeReport() (string, error) {
 	return dotCode, nil
 }
 
+// El journal guarda contenidos de archivos sin importar sus permisos, por eso
+// solo root puede verlo
+func (r *Rep) generateJournalingReport(session *session.Session) (string, error) {
+	if err := r.requireSession(session); err != nil {
+		return "", err
+	}
+
+	if session.UserID != structures.RootUID {
+		return "", fmt.Errorf("permiso denegado: solo root puede generar el reporte %s", r.Name)
+	}
+
+	superBlock, file, _, err := stores.GetSuperBlock(r.Id)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return "", fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", r.Id)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+
+	entries, err := fileSystem.ReadJournal()
+	if err != nil {
+		return "", err
+	}
+
+	return structures.GenerateJournalTable(entries), nil
+}
+
+func (r *Rep) requireSession(session *session.Session) error {
+	if !session.IsLoggedIn || session.PartitionID != r.Id {
+		return fmt.Errorf("debe iniciar sesión en la partición '%s' para generar el reporte %s", r.Id, r.Name)
+	}
+	return nil
+}
+
 func (r *Rep) generateImage(dotCode string) error {
 	format, dotPath, err := r.verifyExtension()
 	if err != nil {
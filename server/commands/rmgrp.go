@@ -34,6 +34,10 @@ func (m *Rmgrp) Execute(session *session.Session) error {
 		return fmt.Errorf("no hay sesión activa: inicie sesión primero")
 	}
 
+	if session.UserID != structures.RootUID {
+		return fmt.Errorf("permiso denegado: solo root puede eliminar grupos")
+	}
+
 	if strings.EqualFold(m.GroupName, "root") {
 		return fmt.Errorf("no se puede eliminar el grupo 'root'")
 	}
@@ -49,91 +53,31 @@ func (m *Rmgrp) Execute(session *session.Session) error {
 	}
 
 	fileSystem := structures.NewFileSystem(file, superBlock)
-	usersInode, usersInodeIndex, err := fileSystem.GetInodeByPath("/users.txt")
-	if err != nil {
+	if err := fileSystem.CheckJournalSpace(); err != nil {
 		return err
 	}
 
-	if usersInode == nil {
-		return fmt.Errorf("el archivo de usuarios no existe")
-	}
-
-	content, err := fileSystem.ReadFileContent(usersInode)
+	users, err := fileSystem.LoadUsers()
 	if err != nil {
 		return err
 	}
 
-	if !strings.HasSuffix(content, "\n") {
-		content += "\n"
-	}
-
-	newContent, err := m.RemoveGroup(content)
-	if err != nil {
+	if err := users.RemoveGroup(m.GroupName); err != nil {
 		return err
 	}
 
-	if err := fileSystem.FreeFileInode(usersInode); err != nil {
+	if err := users.Save(); err != nil {
 		return err
 	}
 
-	AllocatedBlocks, err := fileSystem.AllocateFileBlocks([]byte(newContent))
-	if err != nil {
-		return fmt.Errorf("error al asignar bloques para el nuevo contenido de /users.txt: %v", err)
-	}
-
-	usersInode.Blocks = AllocatedBlocks
-	usersInode.Size = int32(len(newContent))
-	usersInode.UpdateAccessTime()
-	usersInode.UpdateModificationTime()
-
-	offset := int64(fileSystem.Sb.InodeStart + usersInodeIndex*superBlock.InodeSize)
-	if err := utilities.WriteObject(file, *usersInode, offset); err != nil {
+	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
 		return err
 	}
 
-	if err := utilities.WriteObject(file, *superBlock, sbOffset); err != nil {
+	// Se registra al final, cuando la operación ya quedó guardada
+	if err := fileSystem.AppendJournal("rmgrp", structures.UsersFilePath, m.GroupName, session.UserID, session.GroupID); err != nil {
 		return err
 	}
 
 	return nil
 }
-
-func (m *Rmgrp) RemoveGroup(content string) (string, error) {
-	lines := strings.Split(content, "\n")
-	var newContent strings.Builder
-	groupFound := false
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		if trimmedLine == "" {
-			continue
-		}
-
-		fields := strings.Split(trimmedLine, ",")
-		if len(fields) < 3 {
-			newContent.WriteString(trimmedLine + "\n")
-			continue
-		}
-
-		id := strings.TrimSpace(fields[0])
-
-		if id == "0" {
-			newContent.WriteString(trimmedLine + "\n")
-			continue
-		}
-
-		groupName := strings.TrimSpace(fields[2])
-		if strings.EqualFold(groupName, m.GroupName) {
-			groupFound = true
-			fields[0] = "0"
-		}
-
-		newContent.WriteString(strings.Join(fields, ",") + "\n")
-	}
-
-	if !groupFound {
-		return "", fmt.Errorf("el grupo '%s' no existe o ya fue eliminado", m.GroupName)
-	}
-
-	return newContent.String(), nil
-}
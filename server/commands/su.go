@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"strings"
+)
+
+type Su struct {
+	Username string
+	Password string
+	Command  string
+}
+
+// su -user=<usuario> -pass=<contraseña> <comando>. Sin -user se usa root.
+func NewSu(input string) (*Su, error) {
+	params, command := arguments.SplitLeadingParams(input)
+
+	if err := arguments.ValidateParams(params, []string{"user", "pass"}); err != nil {
+		return nil, err
+	}
+
+	username := "root"
+	if strings.Contains(params, "-user=") {
+		var err error
+		username, err = arguments.ParseUser(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	password, err := arguments.ParsePass(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if command == "" {
+		return nil, fmt.Errorf("debe indicar el comando a ejecutar")
+	}
+
+	// Estos comandos cambian la sesión, que aquí es solo prestada
+	switch name := strings.ToLower(strings.Fields(command)[0]); name {
+	case "su", "login", "logout":
+		return nil, fmt.Errorf("el comando '%s' no se puede ejecutar con su", name)
+	}
+
+	return &Su{
+		Username: username,
+		Password: password,
+		Command:  command,
+	}, nil
+}
+
+// Verifica la contraseña del usuario y devuelve una sesión con su identidad
+// para ejecutar el comando. La sesión original no se modifica.
+func (s *Su) Execute(session *session.Session) (*session.Session, error) {
+	if !session.IsLoggedIn {
+		return nil, fmt.Errorf("no hay sesión activa: inicie sesión primero")
+	}
+
+	superBlock, file, _, err := stores.GetSuperBlock(session.PartitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if superBlock.Magic != 0xEF53 {
+		return nil, fmt.Errorf("la partición '%s' no tiene un sistema de archivos ext2 (magic number incorrecto)", session.PartitionID)
+	}
+
+	fileSystem := structures.NewFileSystem(file, superBlock)
+	users, err := fileSystem.LoadUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	user, group, err := users.Authenticate(s.Username, s.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	return session.As(user.Name, group.GID, user.UID, users.UserGroupIDs(user)), nil
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"server/arguments"
+	"server/session"
+	"server/stores"
+	"server/structures"
+	"server/utilities"
+	"strings"
+	"time"
+)
+
+type Unmount struct {
+	Id string
+}
+
+func NewUnmount(input string) (*Unmount, error) {
+	if err := arguments.ValidateParams(input, []string{"id"}); err != nil {
+		return nil, err
+	}
+
+	id, err := arguments.ParseId(input)
+	if err != nil {
+		return nil, fmt.Errorf("error al analizar id: %w", err)
+	}
+
+	return &Unmount{
+		Id: id,
+	}, nil
+}
+
+func (u *Unmount) Execute() error {
+	mountedPartition := stores.MountedPartitions[u.Id]
+	if mountedPartition == nil {
+		return fmt.Errorf("no existe partición montada con ID: %s", u.Id)
+	}
+
+	if session.IsPartitionInUse(u.Id) {
+		return fmt.Errorf("la partición '%s' tiene una sesión activa: cierre sesión antes de desmontarla", u.Id)
+	}
+
+	file, err := utilities.OpenFile(mountedPartition.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	partitionName := strings.Trim(string(mountedPartition.Partition.Name[:]), "\x00 ")
+
+	if mountedPartition.EBRPosition != -1 {
+		var ebr structures.EBR
+		if err = utilities.ReadObject(file, &ebr, int64(mountedPartition.EBRPosition)); err != nil {
+			return fmt.Errorf("error al leer el EBR: %w", err)
+		}
+
+		if strings.Trim(string(ebr.PartName[:]), "\x00 ") != partitionName {
+			return fmt.Errorf("no se encontró la partición '%s' en el disco '%s'", partitionName, mountedPartition.Path)
+		}
+
+		ebr.PartMount = [1]byte{'0'}
+		if err := utilities.WriteObject(file, ebr, int64(mountedPartition.EBRPosition)); err != nil {
+			return fmt.Errorf("error al actualizar el EBR: %w", err)
+		}
+	} else {
+		var mbr structures.MBR
+		if err = utilities.ReadObject(file, &mbr, 0); err != nil {
+			return fmt.Errorf("error al leer el MBR: %w", err)
+		}
+
+		partition := mbr.GetPartitionByName(partitionName)
+		if partition == nil {
+			return fmt.Errorf("no se encontró la partición '%s' en el disco '%s'", partitionName, mountedPartition.Path)
+		}
+
+		partition.Status = [1]byte{'0'}
+		partition.ID = [4]byte{}
+		partition.Correlative = -1
+
+		if err := utilities.WriteObject(file, mbr, 0); err != nil {
+			return fmt.Errorf("error al actualizar el MBR: %w", err)
+		}
+	}
+
+	var superBlock structures.SuperBlock
+	if err = utilities.ReadObject(file, &superBlock, int64(mountedPartition.Partition.Start)); err != nil {
+		return fmt.Errorf("error al leer el superbloque: %w", err)
+	}
+
+	if superBlock.Magic == 0xEF53 {
+		superBlock.Utime = time.Now().Unix()
+		if err = utilities.WriteObject(file, superBlock, int64(mountedPartition.Partition.Start)); err != nil {
+			return fmt.Errorf("error al actualizar el superbloque: %w", err)
+		}
+	}
+
+	if err := stores.ReleaseMountID(u.Id); err != nil {
+		return err
+	}
+
+	return stores.SaveMountTable()
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// Cookie con la que cada cliente conserva su sesión entre peticiones
+const sessionCookie = "session_id"
+
 type Request struct {
 	Script string `json:"script"`
 }
@@ -17,7 +20,7 @@ type Response struct {
 	Output string `json:"output"`
 }
 
-func Execute(c *fiber.Ctx, session *session.Session) error {
+func Execute(c *fiber.Ctx) error {
 	req := new(Request)
 	if err := c.BodyParser(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(Response{
@@ -25,6 +28,26 @@ func Execute(c *fiber.Ctx, session *session.Session) error {
 		})
 	}
 
+	// Sin cookie cada petición recibe una sesión nueva y no hay nada que esperar.
+	// Fiber reutiliza el buffer de la petición, así que la clave se copia.
+	if cookie := c.Cookies(sessionCookie); cookie != "" {
+		defer clientLocks.Lock(strings.Clone(cookie))()
+	}
+
+	token, clientSession, err := session.Acquire(c.Cookies(sessionCookie))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(Response{
+			Output: err.Error(),
+		})
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		HTTPOnly: true,
+		SameSite: "Lax",
+	})
+
 	fmt.Println("Received script:", req.Script)
 
 	script := strings.Split(req.Script, "\n")
@@ -45,7 +68,10 @@ func Execute(c *fiber.Ctx, session *session.Session) error {
 
 		output.WriteString(fmt.Sprint("Resultado línea ", i+1, " — "))
 
-		result, err := analyzer.Analyzer(trimmedCommand, session)
+		unlock := lockCommand(trimmedCommand, clientSession)
+		result, err := analyzer.Analyzer(trimmedCommand, clientSession)
+		unlock()
+
 		if err != nil {
 			output.WriteString(fmt.Sprintf("%s Error%s\n", result, err.Error()))
 		} else {
@@ -53,6 +79,8 @@ func Execute(c *fiber.Ctx, session *session.Session) error {
 		}
 	}
 
+	session.Release(token)
+
 	return c.JSON(Response{
 		Output: output.String(),
 	})
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"server/analyzer"
+	"server/arguments"
+	"server/session"
+	"strings"
+	"sync"
+)
+
+// Comandos que cambian los discos o la tabla de montajes. Se ejecutan solos,
+// sin ningún otro comando en curso.
+var diskCommands = map[string]bool{
+	"mkdisk":  true,
+	"rmdisk":  true,
+	"fdisk":   true,
+	"mount":   true,
+	"unmount": true,
+	"mounted": true,
+}
+
+var (
+	// Lo toman en exclusiva los comandos de disco y compartido los demás
+	disksMu sync.RWMutex
+	// Un comando por partición a la vez
+	partitionLocks = newKeyedMutex()
+	// Las peticiones de un mismo cliente comparten la sesión, así que van una tras otra
+	clientLocks = newKeyedMutex()
+)
+
+// Mutex por clave que se descarta cuando nadie lo usa
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*keyedLock)}
+}
+
+// Bloquea la clave y devuelve la función que la libera
+func (k *keyedMutex) Lock(key string) func() {
+	k.mu.Lock()
+	lock, ok := k.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		k.locks[key] = lock
+	}
+	lock.refs++
+	k.mu.Unlock()
+
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+
+		k.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// Bloquea lo que necesita la línea: todo si es un comando de disco o si no se
+// sabe sobre qué partición trabaja, o solo su partición en otro caso. La
+// partición es la del -id de la línea o, si no trae, la de la sesión. Con su
+// cuenta el comando que envuelve, que es el que llega a ejecutarse.
+func lockCommand(line string, clientSession *session.Session) func() {
+	command := strings.ToLower(strings.Fields(line)[0])
+	if command == "su" {
+		_, inner := arguments.SplitLeadingParams(strings.TrimSpace(line[len(command):]))
+		if inner != "" {
+			line = inner
+			command = strings.ToLower(strings.Fields(line)[0])
+		}
+	}
+
+	partitionID, err := arguments.ParseId(analyzer.NormalizeParamKeys(line))
+	if err != nil && clientSession.IsLoggedIn {
+		partitionID, err = clientSession.PartitionID, nil
+	}
+
+	if diskCommands[command] || err != nil {
+		disksMu.Lock()
+		return disksMu.Unlock
+	}
+
+	disksMu.RLock()
+	unlockPartition := partitionLocks.Lock(partitionID)
+
+	return func() {
+		unlockPartition()
+		disksMu.RUnlock()
+	}
+}
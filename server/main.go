@@ -1,8 +1,9 @@
 package main
 
 import (
+	"fmt"
 	"server/handler"
-	"server/session"
+	"server/stores"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -10,7 +11,14 @@ import (
 
 func main() {
 	app := fiber.New()
-	session := session.NewSession()
+
+	dropped, err := stores.LoadMountTable()
+	if err != nil {
+		fmt.Println("Error al restaurar la tabla de montajes:", err)
+	}
+	for _, entry := range dropped {
+		fmt.Println("Montaje descartado:", entry)
+	}
 
 	app.Use(func(c *fiber.Ctx) error {
 		c.Set("Content-Type", "application/json")
@@ -25,7 +33,7 @@ func main() {
 	}))
 
 	app.Post("/execute", func(c *fiber.Ctx) error {
-		return handler.Execute(c, session)
+		return handler.Execute(c)
 	})
 
 	app.Listen(":8000")
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// Sesiones de los clientes del servidor, indexadas por el token de su cookie
+var (
+	sessionsMu sync.Mutex
+	sessions   = make(map[string]*Session)
+)
+
+// Devuelve la sesión del token. Si el token no existe se crea una sesión nueva
+// con un token generado por el servidor, que es el que se devuelve.
+func Acquire(token string) (string, *Session, error) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	if s, ok := sessions[token]; ok && token != "" {
+		return token, s, nil
+	}
+
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", nil, fmt.Errorf("error al generar el token de sesión: %w", err)
+	}
+
+	token = hex.EncodeToString(buffer)
+	s := NewSession()
+	sessions[token] = s
+	return token, s, nil
+}
+
+// Descarta la sesión si no tiene usuario, para no acumular clientes anónimos
+func Release(token string) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	if s, ok := sessions[token]; ok && !s.IsLoggedIn {
+		delete(sessions, token)
+	}
+}
+
+// Indica si algún cliente tiene una sesión iniciada en la partición
+func IsPartitionInUse(partitionID string) bool {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	for _, s := range sessions {
+		if s.IsLoggedIn && s.PartitionID == partitionID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ type Session struct {
 	IsLoggedIn  bool
 	Username    string
 	GroupID     int32
+	Groups      []int32 // Todos los grupos del usuario, empezando por GroupID
 	UserID      int32
 	PartitionID string
 }
@@ -16,17 +17,31 @@ func NewSession() *Session {
 	}
 }
 
-func (s *Session) Login(username string, groupID, userID int32, partitionID string) {
+func (s *Session) Login(username string, groupID, userID int32, groups []int32, partitionID string) {
 	s.IsLoggedIn = true
 	s.Username = username
 	s.GroupID = groupID
+	s.Groups = groups
 	s.UserID = userID
 	s.PartitionID = partitionID
 }
 
+// Copia de la sesión con la identidad de otro usuario de la misma partición.
+// Sirve para ejecutar un solo comando como ese usuario sin tocar la sesión
+// original.
+func (s *Session) As(username string, groupID, userID int32, groups []int32) *Session {
+	other := *s
+	other.Username = username
+	other.GroupID = groupID
+	other.UserID = userID
+	other.Groups = groups
+	return &other
+}
+
 func (s *Session) Logout() {
 	s.IsLoggedIn = false
 	s.GroupID = -1
+	s.Groups = nil
 	s.UserID = -1
 	s.Username = ""
 	s.PartitionID = ""
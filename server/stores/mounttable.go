@@ -0,0 +1,151 @@
+package stores
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"server/structures"
+	"server/utilities"
+	"strings"
+)
+
+// Archivo donde se guarda la tabla de montajes, relativo al directorio del servidor
+var MountTablePath = "mount_table.json"
+
+type mountTable struct {
+	Partitions      map[string]mountTableEntry `json:"partitions"`
+	Disks           map[string]*MountedDisk    `json:"disks"`
+	NextLetterIndex int                        `json:"next_letter_index"`
+}
+
+type mountTableEntry struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+}
+
+func SaveMountTable() error {
+	table := mountTable{
+		Partitions:      make(map[string]mountTableEntry),
+		Disks:           MountedDisks,
+		NextLetterIndex: nextLetterIndex,
+	}
+
+	for id, mounted := range MountedPartitions {
+		table.Partitions[id] = mountTableEntry{
+			Path: mounted.Path,
+			Name: strings.Trim(string(mounted.Partition.Name[:]), "\x00 "),
+		}
+	}
+
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error al serializar la tabla de montajes: %w", err)
+	}
+
+	// Se escribe en un archivo temporal para no dejar la tabla a medias
+	tmpPath := MountTablePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("error al escribir la tabla de montajes: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, MountTablePath); err != nil {
+		return fmt.Errorf("error al guardar la tabla de montajes: %w", err)
+	}
+
+	return nil
+}
+
+// Restaura la tabla de montajes guardada, descartando las entradas cuyo disco
+// ya no existe o cuya partición ya no figura como montada con ese ID.
+func LoadMountTable() ([]string, error) {
+	data, err := os.ReadFile(MountTablePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al leer la tabla de montajes: %w", err)
+	}
+
+	var table mountTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("error al interpretar la tabla de montajes: %w", err)
+	}
+
+	var dropped []string
+	for id, entry := range table.Partitions {
+		partition, ebrPosition, err := findMountedPartition(entry.Path, entry.Name, id)
+		if err != nil {
+			dropped = append(dropped, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+
+		MountedPartitions[id] = &MountedPartition{
+			Path:        entry.Path,
+			Partition:   partition,
+			EBRPosition: ebrPosition,
+		}
+	}
+
+	for path, disk := range table.Disks {
+		for _, mounted := range MountedPartitions {
+			if mounted.Path == path {
+				MountedDisks[path] = disk
+				break
+			}
+		}
+	}
+
+	nextLetterIndex = min(max(table.NextLetterIndex, 0), len(alphabet))
+
+	if len(dropped) > 0 {
+		if err := SaveMountTable(); err != nil {
+			return dropped, err
+		}
+	}
+
+	return dropped, nil
+}
+
+func findMountedPartition(path string, name string, id string) (*structures.Partition, int32, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, -1, fmt.Errorf("el disco '%s' ya no existe", path)
+	}
+
+	file, err := utilities.OpenFile(path)
+	if err != nil {
+		return nil, -1, err
+	}
+	defer file.Close()
+
+	var mbr structures.MBR
+	if err := utilities.ReadObject(file, &mbr, 0); err != nil {
+		return nil, -1, fmt.Errorf("error al leer el MBR: %w", err)
+	}
+
+	if partition := mbr.GetPartitionByName(name); partition != nil {
+		if partition.Status != [1]byte{'1'} || strings.Trim(string(partition.ID[:]), "\x00 ") != id {
+			return nil, -1, fmt.Errorf("la partición '%s' ya no está montada con el ID %s", name, id)
+		}
+		return partition, -1, nil
+	}
+
+	// Las lógicas no guardan su ID en el EBR, solo se verifica que sigan montadas
+	if extendedPartition := mbr.GetExtendedPartition(); extendedPartition != nil {
+		ebr, ebrPosition, err := structures.FindLogicalPartition(file, extendedPartition.Start, name)
+		if err != nil {
+			return nil, -1, err
+		}
+
+		if ebr != nil {
+			if ebr.PartMount != [1]byte{'1'} {
+				return nil, -1, fmt.Errorf("la partición '%s' ya no está montada con el ID %s", name, id)
+			}
+
+			partition := ebr.ToPartition()
+			copy(partition.ID[:], id)
+			return partition, ebrPosition, nil
+		}
+	}
+
+	return nil, -1, fmt.Errorf("la partición '%s' ya no existe en '%s'", name, path)
+}
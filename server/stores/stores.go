@@ -8,13 +8,14 @@ import (
 )
 
 type MountedPartition struct {
-	Path      string
-	Partition *structures.Partition
+	Path        string
+	Partition   *structures.Partition // Para las lógicas guarda el inicio y tamaño indicados por su EBR
+	EBRPosition int32                 // Posición del EBR si la partición es lógica, -1 si es primaria
 }
 
 type MountedDisk struct {
-	Letter         string
-	PartitionCount int
+	Letter         string `json:"letter"`
+	PartitionCount int    `json:"partition_count"`
 }
 
 var MountedPartitions = make(map[string]*MountedPartition)
@@ -29,22 +30,67 @@ func AllocateMountID(path string) (string, int, error) {
 	disk, exists := MountedDisks[path]
 
 	if !exists {
-		if nextLetterIndex >= len(alphabet) {
-			return "", 0, fmt.Errorf("no hay más letras disponibles para montar discos")
+		letter, err := allocateDiskLetter()
+		if err != nil {
+			return "", 0, err
 		}
 
 		disk = &MountedDisk{
-			Letter:         alphabet[nextLetterIndex],
+			Letter:         letter,
 			PartitionCount: 0,
 		}
 		MountedDisks[path] = disk
-		nextLetterIndex++
 	}
 
 	disk.PartitionCount++
 	return disk.Letter, disk.PartitionCount, nil
 }
 
+// Reutiliza primero las letras de discos que ya fueron desmontados.
+func allocateDiskLetter() (string, error) {
+	for _, letter := range alphabet[:nextLetterIndex] {
+		inUse := false
+		for _, disk := range MountedDisks {
+			if disk.Letter == letter {
+				inUse = true
+				break
+			}
+		}
+
+		if !inUse {
+			return letter, nil
+		}
+	}
+
+	if nextLetterIndex >= len(alphabet) {
+		return "", fmt.Errorf("no hay más letras disponibles para montar discos")
+	}
+
+	letter := alphabet[nextLetterIndex]
+	nextLetterIndex++
+	return letter, nil
+}
+
+// Quita la partición de la tabla de montajes y libera la letra del disco
+// cuando ya no le quedan particiones montadas.
+func ReleaseMountID(id string) error {
+	mountedPartition := MountedPartitions[id]
+	if mountedPartition == nil {
+		return fmt.Errorf("no existe partición montada con ID: %s", id)
+	}
+
+	delete(MountedPartitions, id)
+
+	for _, mounted := range MountedPartitions {
+		if mounted.Path == mountedPartition.Path {
+			return nil
+		}
+	}
+
+	delete(MountedDisks, mountedPartition.Path)
+	return nil
+}
+
 func GetSuperBlock(id string) (*structures.SuperBlock, *os.File, int64, error) {
 	mountedPartition := MountedPartitions[id]
 	if mountedPartition == nil {
@@ -1,11 +1,12 @@
 package structures
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
 	"server/utilities"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 )
@@ -38,17 +39,23 @@ func (fs *FileSystem) CreateUsersFile() error {
 		return fmt.Errorf("no se pudo encontrar un inodo libre para users.txt: %w", err)
 	}
 
-	usersBlockIndex, err := fs.Sb.GetFreeBlockIndex(fs.File)
+	rootInode := NewInode(1, 1, 0, [1]byte{'0'}, [3]byte{'7', '7', '7'})
+	rootInode.PushBlock(rootBlockIndex)
+
+	rootPassword, err := HashPassword("123")
 	if err != nil {
-		return fmt.Errorf("no se pudo encontrar un bloque libre para users.txt: %w", err)
+		return err
 	}
 
-	rootInode := NewInode(1, 1, 0, [1]byte{'0'}, [3]byte{'7', '7', '7'})
-	rootInode.PushBlock(rootBlockIndex)
+	// Con el hash de la contraseña el contenido ya no cabe en un solo bloque
+	usersText := "1,G,root\n1,U,root,root," + rootPassword + "\n"
+	usersBlocks, err := fs.AllocateFileBlocks([]byte(usersText))
+	if err != nil {
+		return fmt.Errorf("no se pudieron asignar bloques para users.txt: %w", err)
+	}
 
-	usersText := "1,G,root\n1,U,root,root,123\n"
 	userInode := NewInode(1, 1, int32(len(usersText)), [1]byte{'1'}, [3]byte{'7', '7', '7'})
-	userInode.PushBlock(usersBlockIndex)
+	userInode.Blocks = usersBlocks
 
 	rootBlock := &FolderBlock{
 		Content: [4]FolderContent{
@@ -59,9 +66,6 @@ func (fs *FileSystem) CreateUsersFile() error {
 		},
 	}
 
-	usersBlock := &FileBlock{}
-	copy(usersBlock.Content[:], usersText)
-
 	rootInodeOffset := int64(fs.Sb.InodeStart) + int64(rootInodeIndex)*int64(fs.Sb.InodeSize)
 	if err := utilities.WriteObject(fs.File, *rootInode, rootInodeOffset); err != nil {
 		return err
@@ -77,11 +81,6 @@ func (fs *FileSystem) CreateUsersFile() error {
 		return err
 	}
 
-	usersBlockOffset := int64(fs.Sb.BlockStart) + int64(usersBlockIndex)*int64(fs.Sb.BlockSize)
-	if err := utilities.WriteObject(fs.File, *usersBlock, usersBlockOffset); err != nil {
-		return err
-	}
-
 	if err := fs.Sb.UpdateInodeBitmap(rootInodeIndex, [1]byte{'1'}, fs.File); err != nil {
 		return err
 	}
@@ -94,14 +93,37 @@ func (fs *FileSystem) CreateUsersFile() error {
 		return err
 	}
 
-	if err := fs.Sb.UpdateBlockBitmap(usersBlockIndex, [1]byte{'1'}, fs.File); err != nil {
-		return err
-	}
-
 	return nil
 }
 
+// Resuelve la ruta sin revisar permisos; es para uso interno del sistema de
+// archivos. Los comandos de usuario usan ResolvePath.
 func (fs *FileSystem) GetInodeByPath(input string) (*Inode, int32, error) {
+	return fs.resolvePath(input, func(folder *Inode, folderPath string, name string) (int32, error) {
+		return fs.GetInodeIndexByName(folder, name)
+	})
+}
+
+// Resuelve la ruta exigiendo permiso de ejecución sobre cada carpeta que se
+// atraviesa, como en un sistema UNIX.
+func (fs *FileSystem) ResolvePath(input string, UID int32, GIDs []int32) (*Inode, int32, error) {
+	return fs.resolvePath(input, func(folder *Inode, folderPath string, name string) (int32, error) {
+		return fs.LookupEntry(folder, folderPath, name, UID, GIDs)
+	})
+}
+
+// Busca una entrada dentro de la carpeta, lo que requiere permiso de ejecución
+// sobre ella.
+func (fs *FileSystem) LookupEntry(folder *Inode, folderPath string, name string, UID int32, GIDs []int32) (int32, error) {
+	if folder.Type == [1]byte{'0'} {
+		if err := fs.RequirePermission(folder, folderPath, UID, GIDs, PermExecute); err != nil {
+			return -1, err
+		}
+	}
+	return fs.GetInodeIndexByName(folder, name)
+}
+
+func (fs *FileSystem) resolvePath(input string, lookup func(folder *Inode, folderPath string, name string) (int32, error)) (*Inode, int32, error) {
 	clean := path.Clean(input)
 	if clean == "/" || clean == "." || clean == "" {
 		var root Inode
@@ -114,13 +136,13 @@ func (fs *FileSystem) GetInodeByPath(input string) (*Inode, int32, error) {
 	parts := strings.FieldsFunc(clean, func(r rune) bool { return r == '/' })
 	currentInodeIndex := int32(0)
 
-	for _, part := range parts {
+	for i, part := range parts {
 		var currentInode Inode
 		if err := utilities.ReadObject(fs.File, &currentInode, int64(fs.Sb.InodeStart+currentInodeIndex*fs.Sb.InodeSize)); err != nil {
 			return nil, -1, err
 		}
 
-		nextInodeIndex, err := fs.GetInodeIndexByName(&currentInode, part)
+		nextInodeIndex, err := lookup(&currentInode, "/"+strings.Join(parts[:i], "/"), part)
 		if err != nil {
 			return nil, -1, err
 		}
@@ -141,11 +163,16 @@ func (fs *FileSystem) GetInodeByPath(input string) (*Inode, int32, error) {
 }
 
 func (fs *FileSystem) GetInodeIndexByName(inode *Inode, name string) (int32, error) {
-	for _, blockIndex := range inode.Blocks {
-		if blockIndex == -1 {
-			continue
-		}
+	if inode.Type != [1]byte{'0'} {
+		return -1, nil
+	}
+
+	folderBlocks, err := fs.GetDataBlocks(inode)
+	if err != nil {
+		return -1, err
+	}
 
+	for _, blockIndex := range folderBlocks {
 		var folderBlock FolderBlock
 		if err := utilities.ReadObject(fs.File, &folderBlock, int64(fs.Sb.BlockStart+blockIndex*fs.Sb.BlockSize)); err != nil {
 			return -1, err
@@ -383,7 +410,7 @@ func (fs *FileSystem) AllocateFileBlocks(content []byte) ([15]int32, error) {
 	numBlocksNeeded := (int32(len(content)) + fs.Sb.BlockSize - 1) / fs.Sb.BlockSize
 	pointerPerBlock := int32(len(PointerBlock{}.Pointers))
 
-	if numBlocksNeeded > fs.Sb.FreeBlocksCount {
+	if numBlocksNeeded+pointerBlocksFor(numBlocksNeeded) > fs.Sb.FreeBlocksCount {
 		return allocatedBlocks, fmt.Errorf("no hay suficientes bloques libres para escribir el archivo")
 	}
 
@@ -533,17 +560,272 @@ func (fs *FileSystem) AllocateFileBlocks(content []byte) ([15]int32, error) {
 	return allocatedBlocks, nil
 }
 
-func (fs *FileSystem) AddEntryToParent(parentInode *Inode, parentInodeIndex int32, entryName string, entryInodeIndex int32) error {
-	firstFreeSlot := -1
+func (fs *FileSystem) GetDataBlocks(inode *Inode) ([]int32, error) {
+	var dataBlocks []int32
 
-	for i, blockIndex := range parentInode.Blocks {
-		if blockIndex == -1 {
-			if firstFreeSlot == -1 {
-				firstFreeSlot = i
+	for i := 0; i < 12; i++ {
+		if inode.Blocks[i] != -1 {
+			dataBlocks = append(dataBlocks, inode.Blocks[i])
+		}
+	}
+
+	var collectRecursive func(level int, blockPtr int32) error
+	collectRecursive = func(level int, blockPtr int32) error {
+		if blockPtr == -1 {
+			return nil
+		}
+
+		if blockPtr < 0 || blockPtr >= fs.Sb.BlocksCount {
+			return fmt.Errorf("puntero de bloque inválido: %d", blockPtr)
+		}
+
+		offset := int64(fs.Sb.BlockStart + blockPtr*fs.Sb.BlockSize)
+		var pointerBlock PointerBlock
+		if err := utilities.ReadObject(fs.File, &pointerBlock, offset); err != nil {
+			return err
+		}
+
+		for _, nextPtr := range pointerBlock.Pointers {
+			if nextPtr == -1 {
+				continue
+			}
+
+			if level == 1 {
+				if nextPtr < 0 || nextPtr >= fs.Sb.BlocksCount {
+					return fmt.Errorf("puntero de bloque inválido: %d", nextPtr)
+				}
+				dataBlocks = append(dataBlocks, nextPtr)
+			} else if err := collectRecursive(level-1, nextPtr); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for level := 1; level <= 3; level++ {
+		if err := collectRecursive(level, inode.Blocks[11+level]); err != nil {
+			return nil, fmt.Errorf("error en indirección de nivel %d: %w", level, err)
+		}
+	}
+
+	return dataBlocks, nil
+}
+
+// Reescribe el contenido de un archivo reutilizando sus bloques actuales y
+// liberando o reservando solo la diferencia. No persiste el inodo ni el superbloque.
+func (fs *FileSystem) WriteFileContent(inode *Inode, content []byte) error {
+	if inode.Type != [1]byte{'1'} {
+		return fmt.Errorf("el inodo no es un archivo regular")
+	}
+
+	oldBlocks, err := fs.GetDataBlocks(inode)
+	if err != nil {
+		return err
+	}
+
+	pointerPerBlock := int32(len(PointerBlock{}.Pointers))
+	maxBlocks := 12 + pointerPerBlock + pointerPerBlock*pointerPerBlock + pointerPerBlock*pointerPerBlock*pointerPerBlock
+	numBlocksNeeded := (int32(len(content)) + fs.Sb.BlockSize - 1) / fs.Sb.BlockSize
+
+	if numBlocksNeeded > maxBlocks {
+		return fmt.Errorf("el contenido excede el tamaño máximo de un archivo")
+	}
+
+	// Los bloques de punteros también se reservan o liberan según la cantidad
+	newTotal := numBlocksNeeded + pointerBlocksFor(numBlocksNeeded)
+	oldTotal := int32(len(oldBlocks)) + pointerBlocksFor(int32(len(oldBlocks)))
+	if newTotal-oldTotal > fs.Sb.FreeBlocksCount {
+		return fmt.Errorf("no hay suficientes bloques libres para escribir el archivo")
+	}
+
+	newBlocks := make([]int32, numBlocksNeeded)
+	for i := int32(0); i < numBlocksNeeded; i++ {
+		if i < int32(len(oldBlocks)) {
+			newBlocks[i] = oldBlocks[i]
+		} else {
+			blockIndex, err := fs.Sb.GetFreeBlockIndex(fs.File)
+			if err != nil {
+				return fmt.Errorf("error al obtener bloque libre: %w", err)
+			}
+			if err := fs.Sb.UpdateBlockBitmap(blockIndex, [1]byte{'1'}, fs.File); err != nil {
+				return err
+			}
+			newBlocks[i] = blockIndex
+		}
+
+		fileBlock, err := NewFileBlock(content, i*fs.Sb.BlockSize, fs.Sb.BlockSize)
+		if err != nil {
+			return fmt.Errorf("error al crear bloque de archivo: %w", err)
+		}
+
+		offset := int64(fs.Sb.BlockStart + newBlocks[i]*fs.Sb.BlockSize)
+		if err := utilities.WriteObject(fs.File, *fileBlock, offset); err != nil {
+			return fmt.Errorf("error al escribir bloque de archivo: %w", err)
+		}
+	}
+
+	for _, blockIndex := range oldBlocks[min(len(newBlocks), len(oldBlocks)):] {
+		if err := fs.Sb.UpdateBlockBitmap(blockIndex, [1]byte{'0'}, fs.File); err != nil {
+			return err
+		}
+	}
+
+	if err := fs.SetDataBlocks(inode, newBlocks); err != nil {
+		return err
+	}
+
+	inode.Size = int32(len(content))
+	inode.UpdateModificationTime()
+	return nil
+}
+
+// Cantidad de bloques de punteros que ocupa un archivo de dataBlocks bloques
+// de datos, repartidos como lo hace SetDataBlocks.
+func pointerBlocksFor(dataBlocks int32) int32 {
+	pointerPerBlock := int32(len(PointerBlock{}.Pointers))
+	remaining := max(dataBlocks-12, 0)
+	var total int32
+
+	capacity := int32(1)
+	for level := 1; level <= 3 && remaining > 0; level++ {
+		capacity *= pointerPerBlock
+		chunk := min(remaining, capacity)
+		remaining -= chunk
+
+		// Un bloque por cada grupo de punteros en cada nivel de este árbol
+		groupSize := capacity
+		for i := 0; i < level; i++ {
+			groupSize /= pointerPerBlock
+			total += (chunk + groupSize*pointerPerBlock - 1) / (groupSize * pointerPerBlock)
+		}
+	}
+
+	return total
+}
+
+// Distribuye los bloques de datos en los apuntadores directos e indirectos del
+// inodo, reutilizando los bloques de punteros existentes. No persiste el inodo.
+func (fs *FileSystem) SetDataBlocks(inode *Inode, dataBlocks []int32) error {
+	pointerPerBlock := len(PointerBlock{}.Pointers)
+	if len(dataBlocks) > 12+pointerPerBlock+pointerPerBlock*pointerPerBlock+pointerPerBlock*pointerPerBlock*pointerPerBlock {
+		return fmt.Errorf("se excedió la cantidad máxima de bloques de un inodo")
+	}
+
+	for i := 0; i < 12; i++ {
+		if i < len(dataBlocks) {
+			inode.Blocks[i] = dataBlocks[i]
+		} else {
+			inode.Blocks[i] = -1
+		}
+	}
+
+	remaining := dataBlocks[min(12, len(dataBlocks)):]
+	capacity := 1
+	for level := 1; level <= 3; level++ {
+		capacity *= pointerPerBlock
+		chunk := remaining[:min(capacity, len(remaining))]
+		remaining = remaining[len(chunk):]
+
+		pointerIndex, err := fs.syncPointerBlock(inode.Blocks[11+level], level, chunk)
+		if err != nil {
+			return fmt.Errorf("error en indirección de nivel %d: %w", level, err)
+		}
+		inode.Blocks[11+level] = pointerIndex
+	}
+
+	return nil
+}
+
+// Ajusta un bloque de punteros del nivel indicado para que apunte a dataBlocks,
+// reutilizándolo si ya existe. Devuelve -1 cuando el bloque deja de ser necesario.
+func (fs *FileSystem) syncPointerBlock(blockPtr int32, level int, dataBlocks []int32) (int32, error) {
+	var pointerBlock *PointerBlock
+
+	if blockPtr != -1 {
+		if blockPtr < 0 || blockPtr >= fs.Sb.BlocksCount {
+			return -1, fmt.Errorf("puntero de bloque inválido: %d", blockPtr)
+		}
+
+		pointerBlock = &PointerBlock{}
+		offset := int64(fs.Sb.BlockStart + blockPtr*fs.Sb.BlockSize)
+		if err := utilities.ReadObject(fs.File, pointerBlock, offset); err != nil {
+			return -1, err
+		}
+	}
+
+	if len(dataBlocks) == 0 {
+		if pointerBlock == nil {
+			return -1, nil
+		}
+
+		if level > 1 {
+			for _, nextPtr := range pointerBlock.Pointers {
+				if _, err := fs.syncPointerBlock(nextPtr, level-1, nil); err != nil {
+					return -1, err
+				}
+			}
+		}
+
+		if err := fs.Sb.UpdateBlockBitmap(blockPtr, [1]byte{'0'}, fs.File); err != nil {
+			return -1, err
+		}
+		return -1, nil
+	}
+
+	if pointerBlock == nil {
+		newBlockIndex, err := fs.Sb.GetFreeBlockIndex(fs.File)
+		if err != nil {
+			return -1, fmt.Errorf("error al obtener bloque libre para punteros: %w", err)
+		}
+		if err := fs.Sb.UpdateBlockBitmap(newBlockIndex, [1]byte{'1'}, fs.File); err != nil {
+			return -1, err
+		}
+		blockPtr = newBlockIndex
+		pointerBlock = NewPointerBlock()
+	}
+
+	childCapacity := 1
+	for i := 1; i < level; i++ {
+		childCapacity *= len(pointerBlock.Pointers)
+	}
+
+	for i := range pointerBlock.Pointers {
+		start := min(i*childCapacity, len(dataBlocks))
+		end := min(start+childCapacity, len(dataBlocks))
+		chunk := dataBlocks[start:end]
+
+		if level == 1 {
+			if len(chunk) == 1 {
+				pointerBlock.Pointers[i] = chunk[0]
+			} else {
+				pointerBlock.Pointers[i] = -1
 			}
 			continue
 		}
 
+		childPtr, err := fs.syncPointerBlock(pointerBlock.Pointers[i], level-1, chunk)
+		if err != nil {
+			return -1, err
+		}
+		pointerBlock.Pointers[i] = childPtr
+	}
+
+	offset := int64(fs.Sb.BlockStart + blockPtr*fs.Sb.BlockSize)
+	if err := utilities.WriteObject(fs.File, *pointerBlock, offset); err != nil {
+		return -1, fmt.Errorf("error al escribir bloque de punteros: %w", err)
+	}
+
+	return blockPtr, nil
+}
+
+func (fs *FileSystem) AddEntryToParent(parentInode *Inode, parentInodeIndex int32, entryName string, entryInodeIndex int32) error {
+	folderBlocks, err := fs.GetDataBlocks(parentInode)
+	if err != nil {
+		return fmt.Errorf("error al leer los bloques del directorio: %w", err)
+	}
+
+	for _, blockIndex := range folderBlocks {
 		offset := int64(fs.Sb.BlockStart + blockIndex*fs.Sb.BlockSize)
 		var folderBlock FolderBlock
 		if err := utilities.ReadObject(fs.File, &folderBlock, offset); err != nil {
@@ -563,17 +845,11 @@ func (fs *FileSystem) AddEntryToParent(parentInode *Inode, parentInodeIndex int3
 		}
 	}
 
-	if firstFreeSlot == -1 {
-		return fmt.Errorf("no hay espacio libre en el directorio")
-
-	}
-
 	newBlockIndex, err := fs.Sb.GetFreeBlockIndex(fs.File)
 	if err != nil {
 		return fmt.Errorf("no se pudo obtener un bloque libre para el nuevo bloque de carpeta: %w", err)
 	}
 
-	parentInode.Blocks[firstFreeSlot] = newBlockIndex
 	newFolderBlock := NewFolderBlock()
 	newFolderBlock.Content[0].Inode = entryInodeIndex
 	copy(newFolderBlock.Content[0].Name[:], entryName)
@@ -587,6 +863,14 @@ func (fs *FileSystem) AddEntryToParent(parentInode *Inode, parentInodeIndex int3
 		return err
 	}
 
+	// Los bloques que no caben en los apuntadores directos pasan a los indirectos
+	if err := fs.SetDataBlocks(parentInode, append(folderBlocks, newBlockIndex)); err != nil {
+		if freeErr := fs.Sb.UpdateBlockBitmap(newBlockIndex, [1]byte{'0'}, fs.File); freeErr != nil {
+			return freeErr
+		}
+		return fmt.Errorf("no hay espacio libre en el directorio: %w", err)
+	}
+
 	parentInode.UpdateModificationTime()
 	parentOffset := int64(fs.Sb.InodeStart + parentInodeIndex*fs.Sb.InodeSize)
 	if err := utilities.WriteObject(fs.File, *parentInode, parentOffset); err != nil {
@@ -607,7 +891,7 @@ func (fs *FileSystem) CreateNewFolder(parentIndex int32, UID int32, GID int32) (
 		return -1, fmt.Errorf("no se pudo encontrar un bloque libre para la nueva carpeta: %w", err)
 	}
 
-	folderInode := NewInode(UID, GID, 0, [1]byte{'0'}, [3]byte{'6', '6', '4'})
+	folderInode := NewInode(UID, GID, 0, [1]byte{'0'}, [3]byte{'7', '7', '5'})
 	folderInode.PushBlock(folderBlockIndex)
 
 	folderBlock := NewFolderBlock()
@@ -635,11 +919,55 @@ func (fs *FileSystem) CreateNewFolder(parentIndex int32, UID int32, GID int32) (
 	return folderInodeIndex, nil
 }
 
-func (fs *FileSystem) EnsurePathExist(path string, UID int32, GID int32) (*Inode, int32, error) {
+// Crea un archivo regular con el contenido indicado y lo enlaza en la carpeta padre
+func (fs *FileSystem) CreateFile(parentInode *Inode, parentInodeIndex int32, fileName string, content []byte, UID int32, GID int32) (int32, error) {
+	fileInodeIndex, err := fs.Sb.GetFreeInodeIndex(fs.File)
+	if err != nil {
+		return -1, err
+	}
+
+	allocatedBlocks, err := fs.AllocateFileBlocks(content)
+	if err != nil {
+		return -1, fmt.Errorf("error al asignar bloques para el contenido del archivo: %w", err)
+	}
+
+	fileInode := NewInode(UID, GID, int32(len(content)), [1]byte{'1'}, [3]byte{'6', '4', '4'})
+	fileInode.Blocks = allocatedBlocks
+	fileInode.UpdateModificationTime()
+
+	fileInodeOffset := int64(fs.Sb.InodeStart) + int64(fileInodeIndex)*int64(fs.Sb.InodeSize)
+	if err := utilities.WriteObject(fs.File, *fileInode, fileInodeOffset); err != nil {
+		return -1, err
+	}
+
+	if err := fs.AddEntryToParent(parentInode, parentInodeIndex, fileName, fileInodeIndex); err != nil {
+		return -1, err
+	}
+
+	if err := fs.Sb.UpdateInodeBitmap(fileInodeIndex, [1]byte{'1'}, fs.File); err != nil {
+		return -1, err
+	}
+
+	return fileInodeIndex, nil
+}
+
+// Crea las carpetas que falten en la ruta. GIDs son los grupos del usuario; el
+// primero es el principal y queda como grupo de las carpetas nuevas.
+func (fs *FileSystem) EnsurePathExist(path string, UID int32, GIDs []int32) (*Inode, int32, error) {
+	return fs.ensurePath(path, UID, GIDs, true)
+}
+
+// Crea las carpetas que falten a nombre del usuario indicado sin revisar
+// permisos. Lo usa recovery para rehacer operaciones del journal.
+func (fs *FileSystem) RestorePath(path string, UID int32, GID int32) (*Inode, int32, error) {
+	return fs.ensurePath(path, UID, []int32{GID}, false)
+}
+
+func (fs *FileSystem) ensurePath(path string, UID int32, GIDs []int32, checkPermissions bool) (*Inode, int32, error) {
 	parts := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
 	currentInodeIndex := int32(0)
 
-	for _, part := range parts {
+	for i, part := range parts {
 		var currentInode Inode
 		if err := utilities.ReadObject(fs.File, &currentInode, int64(fs.Sb.InodeStart+currentInodeIndex*fs.Sb.InodeSize)); err != nil {
 			return nil, -1, err
@@ -649,13 +977,26 @@ func (fs *FileSystem) EnsurePathExist(path string, UID int32, GID int32) (*Inode
 			return nil, -1, fmt.Errorf("no se puede crear: '%s' no es un directorio en la ruta '%s'", part, path)
 		}
 
+		currentPath := "/" + strings.Join(parts[:i], "/")
+		if checkPermissions {
+			if err := fs.RequirePermission(&currentInode, currentPath, UID, GIDs, PermExecute); err != nil {
+				return nil, -1, err
+			}
+		}
+
 		nextInodeIndex, err := fs.GetInodeIndexByName(&currentInode, part)
 		if err != nil {
 			return nil, -1, err
 		}
 
 		if nextInodeIndex == -1 {
-			newFolderInodeIndex, err := fs.CreateNewFolder(currentInodeIndex, UID, GID)
+			if checkPermissions {
+				if err := fs.RequirePermission(&currentInode, currentPath, UID, GIDs, PermWrite); err != nil {
+					return nil, -1, err
+				}
+			}
+
+			newFolderInodeIndex, err := fs.CreateNewFolder(currentInodeIndex, UID, GIDs[0])
 			if err != nil {
 				return nil, -1, err
 			}
@@ -704,11 +1045,12 @@ func (fs *FileSystem) GenerateLsDOT(path string) (string, error) {
 		<td bgcolor="#4CAF50"><b>Name</b></td>
 	</tr>`)
 
-	for _, blockIndex := range inode.Blocks {
-		if blockIndex == -1 {
-			continue
-		}
+	folderBlocks, err := fs.GetDataBlocks(inode)
+	if err != nil {
+		return "", err
+	}
 
+	for _, blockIndex := range folderBlocks {
 		var folderBlock FolderBlock
 		offset := int64(fs.Sb.BlockStart + blockIndex*fs.Sb.BlockSize)
 		if err := utilities.ReadObject(fs.File, &folderBlock, offset); err != nil {
@@ -764,12 +1106,7 @@ func (fs *FileSystem) GenerateLsDOT(path string) (string, error) {
 }
 
 func (fs *FileSystem) BuildUserMaps() (map[int32]string, map[int32]string, error) {
-	usersInode, _, err := fs.GetInodeByPath("/users.txt")
-	if err != nil {
-		return nil, nil, err
-	}
-
-	content, err := fs.ReadFileContent(usersInode)
+	users, err := fs.LoadUsers()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -777,29 +1114,12 @@ func (fs *FileSystem) BuildUserMaps() (map[int32]string, map[int32]string, error
 	userMap := make(map[int32]string)
 	groupMap := make(map[int32]string)
 
-	lines := strings.SplitSeq(content, "\n")
-	for line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		if trimmedLine == "" {
-			continue
-		}
-		fields := strings.Split(trimmedLine, ",")
-		if len(fields) < 3 {
-			continue
-		}
-
-		id, err := strconv.ParseInt(strings.TrimSpace(fields[0]), 10, 32)
-		if err != nil || id == 0 {
-			continue
-		}
-
-		lineType := strings.TrimSpace(fields[1])
+	for _, user := range users.Users() {
+		userMap[user.UID] = user.Name
+	}
 
-		if lineType == "U" && len(fields) >= 4 {
-			userMap[int32(id)] = strings.TrimSpace(fields[3])
-		} else if lineType == "G" {
-			groupMap[int32(id)] = strings.TrimSpace(fields[2])
-		}
+	for _, group := range users.Groups() {
+		groupMap[group.GID] = group.Name
 	}
 
 	return userMap, groupMap, nil
@@ -858,35 +1178,8 @@ func (fs *FileSystem) generateTreeRecursive(inodeIndex int32, dot *strings.Build
 		blockOffset := int64(fs.Sb.BlockStart + blockIndex*fs.Sb.BlockSize)
 		if k < 12 { // Bloques de Directos
 			if inode.Type[0] == '0' { // Carpeta
-				var folderBlock FolderBlock
-				if utilities.ReadObject(fs.File, &folderBlock, blockOffset) == nil {
-					dot.WriteString(folderBlock.GenerateTable(blockIndex))
-					generatedNodes[blockNodeID] = true
-
-					for entryIdx, entry := range folderBlock.Content {
-						if entry.Inode == -1 {
-							continue
-						}
-						entryName := strings.TrimRight(string(entry.Name[:]), "\x00")
-
-						// Ignorar entradas especiales
-						if entryName != "." && entryName != ".." {
-							// Dibujar flecha Bloque -> Inodo
-							childInodeID := fmt.Sprintf("inode%d:top", entry.Inode)
-							folderPort := fmt.Sprintf("i%d", entryIdx)
-
-							childEdgeID := fmt.Sprintf("%s:%s -> %s", blockNodeID, folderPort, childInodeID)
-							if !generatedEdges[childEdgeID] {
-								dot.WriteString(childEdgeID + ";")
-								generatedEdges[childEdgeID] = true
-							}
-
-							// Recursión
-							if err := fs.generateTreeRecursive(entry.Inode, dot, generatedNodes, generatedEdges); err != nil {
-								return fmt.Errorf("error generando árbol recursivo para inodo %d: %v", entry.Inode, err)
-							}
-						}
-					}
+				if err := fs.generateFolderBlockTree(blockIndex, dot, generatedNodes, generatedEdges); err != nil {
+					return err
 				}
 			} else { // Archivo
 				var fileBlock FileBlock
@@ -906,9 +1199,53 @@ func (fs *FileSystem) generateTreeRecursive(inodeIndex int32, dot *strings.Build
 			case 14:
 				level = 3
 			}
-			fs.processPointerBlock(blockIndex, level, originalInodeType, dot, generatedNodes, generatedEdges)
+			if err := fs.processPointerBlock(blockIndex, level, originalInodeType, dot, generatedNodes, generatedEdges); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Dibuja un bloque de carpeta y continúa el árbol por cada una de sus entradas.
+func (fs *FileSystem) generateFolderBlockTree(blockIndex int32, dot *strings.Builder, generatedNodes map[string]bool, generatedEdges map[string]bool) error {
+	blockNodeID := fmt.Sprintf("block%d", blockIndex)
+
+	var folderBlock FolderBlock
+	blockOffset := int64(fs.Sb.BlockStart + blockIndex*fs.Sb.BlockSize)
+	if utilities.ReadObject(fs.File, &folderBlock, blockOffset) != nil {
+		return nil
+	}
+	dot.WriteString(folderBlock.GenerateTable(blockIndex))
+	generatedNodes[blockNodeID] = true
+
+	for entryIdx, entry := range folderBlock.Content {
+		if entry.Inode == -1 {
+			continue
+		}
+		entryName := strings.TrimRight(string(entry.Name[:]), "\x00")
+
+		// Ignorar entradas especiales
+		if entryName == "." || entryName == ".." {
+			continue
+		}
+
+		// Dibujar flecha Bloque -> Inodo
+		childInodeID := fmt.Sprintf("inode%d:top", entry.Inode)
+		folderPort := fmt.Sprintf("i%d", entryIdx)
+
+		childEdgeID := fmt.Sprintf("%s:%s -> %s", blockNodeID, folderPort, childInodeID)
+		if !generatedEdges[childEdgeID] {
+			dot.WriteString(childEdgeID + ";")
+			generatedEdges[childEdgeID] = true
+		}
+
+		// Recursión
+		if err := fs.generateTreeRecursive(entry.Inode, dot, generatedNodes, generatedEdges); err != nil {
+			return fmt.Errorf("error generando árbol recursivo para inodo %d: %v", entry.Inode, err)
 		}
 	}
+
 	return nil
 }
 
@@ -918,19 +1255,19 @@ func (fs *FileSystem) processPointerBlock(
 	originalInodeType byte, // '0' para carpeta, '1' para archivo
 	dot *strings.Builder,
 	generatedNodes map[string]bool,
-	generatedEdges map[string]bool) {
+	generatedEdges map[string]bool) error {
 
 	// Evitar procesar el mismo bloque de punteros múltiples veces
 	blockNodeID := fmt.Sprintf("block%d", pointerBlockIndex)
 	if generatedNodes[blockNodeID] {
-		return
+		return nil
 	}
 
 	// Dibujar el bloque de punteros actual
 	var pBlock PointerBlock
 	blockOffset := int64(fs.Sb.BlockStart + pointerBlockIndex*fs.Sb.BlockSize)
 	if err := utilities.ReadObject(fs.File, &pBlock, blockOffset); err != nil {
-		return
+		return nil
 	}
 	dot.WriteString(pBlock.GenerateTable(pointerBlockIndex))
 	generatedNodes[blockNodeID] = true
@@ -953,7 +1290,9 @@ func (fs *FileSystem) processPointerBlock(
 
 		if level > 1 {
 			// Recursión: Este puntero apunta a OTRO bloque de punteros
-			fs.processPointerBlock(ptrIndex, level-1, originalInodeType, dot, generatedNodes, generatedEdges)
+			if err := fs.processPointerBlock(ptrIndex, level-1, originalInodeType, dot, generatedNodes, generatedEdges); err != nil {
+				return err
+			}
 		} else {
 			// Caso base: Este puntero apunta a un bloque de datos (archivo o carpeta)
 			if generatedNodes[childNodeID] {
@@ -962,10 +1301,8 @@ func (fs *FileSystem) processPointerBlock(
 
 			dataBlockOffset := int64(fs.Sb.BlockStart + ptrIndex*fs.Sb.BlockSize)
 			if originalInodeType == '0' { // Carpeta
-				var folderBlock FolderBlock
-				if utilities.ReadObject(fs.File, &folderBlock, dataBlockOffset) == nil {
-					dot.WriteString(folderBlock.GenerateTable(ptrIndex))
-					generatedNodes[childNodeID] = true
+				if err := fs.generateFolderBlockTree(ptrIndex, dot, generatedNodes, generatedEdges); err != nil {
+					return err
 				}
 			} else { // Archivo
 				var fileBlock FileBlock
@@ -976,4 +1313,427 @@ func (fs *FileSystem) processPointerBlock(
 			}
 		}
 	}
+
+	return nil
+}
+
+func (fs *FileSystem) RemoveEntryFromParent(parentInode *Inode, parentInodeIndex int32, entryName string) error {
+	folderBlocks, err := fs.GetDataBlocks(parentInode)
+	if err != nil {
+		return fmt.Errorf("error al leer los bloques del directorio: %w", err)
+	}
+
+	for i, blockIndex := range folderBlocks {
+		offset := int64(fs.Sb.BlockStart + blockIndex*fs.Sb.BlockSize)
+		var folderBlock FolderBlock
+		if err := utilities.ReadObject(fs.File, &folderBlock, offset); err != nil {
+			return fmt.Errorf("error al leer bloque de carpeta: %w", err)
+		}
+
+		for j := range folderBlock.Content {
+			if folderBlock.Content[j].Inode == -1 {
+				continue
+			}
+
+			name := strings.TrimRight(string(folderBlock.Content[j].Name[:]), "\x00")
+			if name != entryName {
+				continue
+			}
+
+			folderBlock.Content[j] = FolderContent{Name: [12]byte{'-'}, Inode: -1}
+
+			// El primer bloque conserva "." y "..", los demás se liberan al quedar vacíos
+			if i > 0 && folderBlock.IsEmpty() {
+				if err := fs.Sb.UpdateBlockBitmap(blockIndex, [1]byte{'0'}, fs.File); err != nil {
+					return err
+				}
+				if err := fs.SetDataBlocks(parentInode, append(folderBlocks[:i:i], folderBlocks[i+1:]...)); err != nil {
+					return err
+				}
+			} else if err := utilities.WriteObject(fs.File, folderBlock, offset); err != nil {
+				return fmt.Errorf("no se pudo escribir el bloque de directorio modificado %d: %w", blockIndex, err)
+			}
+
+			parentInode.UpdateModificationTime()
+			parentOffset := int64(fs.Sb.InodeStart + parentInodeIndex*fs.Sb.InodeSize)
+			return utilities.WriteObject(fs.File, *parentInode, parentOffset)
+		}
+	}
+
+	return fmt.Errorf("la entrada '%s' no existe en el directorio", entryName)
+}
+
+func (fs *FileSystem) RenameEntryInParent(parentInode *Inode, parentInodeIndex int32, oldName string, newName string) error {
+	folderBlocks, err := fs.GetDataBlocks(parentInode)
+	if err != nil {
+		return err
+	}
+
+	for _, blockIndex := range folderBlocks {
+		offset := int64(fs.Sb.BlockStart + blockIndex*fs.Sb.BlockSize)
+		var folderBlock FolderBlock
+		if err := utilities.ReadObject(fs.File, &folderBlock, offset); err != nil {
+			return fmt.Errorf("error al leer bloque de carpeta: %w", err)
+		}
+
+		for j := range folderBlock.Content {
+			if folderBlock.Content[j].Inode == -1 {
+				continue
+			}
+
+			name := strings.TrimRight(string(folderBlock.Content[j].Name[:]), "\x00")
+			if name != oldName {
+				continue
+			}
+
+			folderBlock.Content[j].Name = [12]byte{}
+			copy(folderBlock.Content[j].Name[:], newName)
+
+			if err := utilities.WriteObject(fs.File, folderBlock, offset); err != nil {
+				return fmt.Errorf("no se pudo escribir el bloque de directorio modificado %d: %w", blockIndex, err)
+			}
+
+			parentInode.UpdateModificationTime()
+			parentOffset := int64(fs.Sb.InodeStart + parentInodeIndex*fs.Sb.InodeSize)
+			return utilities.WriteObject(fs.File, *parentInode, parentOffset)
+		}
+	}
+
+	return fmt.Errorf("la entrada '%s' no existe en el directorio", oldName)
+}
+
+func (fs *FileSystem) SetFolderParent(folderInodeIndex int32, parentInodeIndex int32) error {
+	var folderInode Inode
+	if err := utilities.ReadObject(fs.File, &folderInode, int64(fs.Sb.InodeStart+folderInodeIndex*fs.Sb.InodeSize)); err != nil {
+		return err
+	}
+
+	if folderInode.Type != [1]byte{'0'} {
+		return fmt.Errorf("el inodo %d no es una carpeta", folderInodeIndex)
+	}
+
+	folderBlocks, err := fs.GetDataBlocks(&folderInode)
+	if err != nil {
+		return err
+	}
+
+	for _, blockIndex := range folderBlocks {
+		offset := int64(fs.Sb.BlockStart + blockIndex*fs.Sb.BlockSize)
+		var folderBlock FolderBlock
+		if err := utilities.ReadObject(fs.File, &folderBlock, offset); err != nil {
+			return fmt.Errorf("error al leer bloque de carpeta: %w", err)
+		}
+
+		for j := range folderBlock.Content {
+			name := strings.TrimRight(string(folderBlock.Content[j].Name[:]), "\x00")
+			if name != ".." {
+				continue
+			}
+
+			folderBlock.Content[j].Inode = parentInodeIndex
+			return utilities.WriteObject(fs.File, folderBlock, offset)
+		}
+	}
+
+	return fmt.Errorf("la carpeta %d no tiene una entrada '..'", folderInodeIndex)
+}
+
+func (fs *FileSystem) CheckTreeWritePermission(inodeIndex int32, entryPath string, UID int32, GIDs []int32) error {
+	var inode Inode
+	if err := utilities.ReadObject(fs.File, &inode, int64(fs.Sb.InodeStart+inodeIndex*fs.Sb.InodeSize)); err != nil {
+		return err
+	}
+
+	// Vaciar una carpeta también requiere poder entrar en ella
+	perm := PermWrite
+	if inode.Type == [1]byte{'0'} {
+		perm |= PermExecute
+	}
+
+	if err := fs.RequirePermission(&inode, entryPath, UID, GIDs, perm); err != nil {
+		return err
+	}
+
+	if inode.Type != [1]byte{'0'} {
+		return nil
+	}
+
+	folderBlocks, err := fs.GetDataBlocks(&inode)
+	if err != nil {
+		return err
+	}
+
+	for _, blockIndex := range folderBlocks {
+		var folderBlock FolderBlock
+		if err := utilities.ReadObject(fs.File, &folderBlock, int64(fs.Sb.BlockStart+blockIndex*fs.Sb.BlockSize)); err != nil {
+			return err
+		}
+
+		for _, entry := range folderBlock.Content {
+			if entry.Inode == -1 {
+				continue
+			}
+
+			entryName := strings.TrimRight(string(entry.Name[:]), "\x00")
+			if entryName == "." || entryName == ".." {
+				continue
+			}
+
+			if err := fs.CheckTreeWritePermission(entry.Inode, path.Join(entryPath, entryName), UID, GIDs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+var ErrSkipFolder = errors.New("omitir carpeta")
+
+// Recorre en preorden el inodo indicado y, si es carpeta, todo su contenido.
+// Si visit devuelve ErrSkipFolder no se desciende en esa carpeta.
+func (fs *FileSystem) WalkInodeTree(inodeIndex int32, entryPath string, visit func(inode *Inode, inodeIndex int32, entryPath string) error) error {
+	var inode Inode
+	if err := utilities.ReadObject(fs.File, &inode, int64(fs.Sb.InodeStart+inodeIndex*fs.Sb.InodeSize)); err != nil {
+		return err
+	}
+
+	if err := visit(&inode, inodeIndex, entryPath); err != nil {
+		if err == ErrSkipFolder {
+			return nil
+		}
+		return err
+	}
+
+	if inode.Type != [1]byte{'0'} {
+		return nil
+	}
+
+	folderBlocks, err := fs.GetDataBlocks(&inode)
+	if err != nil {
+		return err
+	}
+
+	for _, blockIndex := range folderBlocks {
+		var folderBlock FolderBlock
+		if err := utilities.ReadObject(fs.File, &folderBlock, int64(fs.Sb.BlockStart+blockIndex*fs.Sb.BlockSize)); err != nil {
+			return err
+		}
+
+		for _, entry := range folderBlock.Content {
+			if entry.Inode == -1 {
+				continue
+			}
+
+			entryName := strings.TrimRight(string(entry.Name[:]), "\x00")
+			if entryName == "." || entryName == ".." {
+				continue
+			}
+
+			if err := fs.WalkInodeTree(entry.Inode, path.Join(entryPath, entryName), visit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (fs *FileSystem) FreeInodeTree(inodeIndex int32) error {
+	var inode Inode
+	offset := int64(fs.Sb.InodeStart + inodeIndex*fs.Sb.InodeSize)
+	if err := utilities.ReadObject(fs.File, &inode, offset); err != nil {
+		return err
+	}
+
+	if inode.Type == [1]byte{'0'} {
+		folderBlocks, err := fs.GetDataBlocks(&inode)
+		if err != nil {
+			return err
+		}
+
+		for _, blockIndex := range folderBlocks {
+			var folderBlock FolderBlock
+			if err := utilities.ReadObject(fs.File, &folderBlock, int64(fs.Sb.BlockStart+blockIndex*fs.Sb.BlockSize)); err != nil {
+				return err
+			}
+
+			for _, entry := range folderBlock.Content {
+				if entry.Inode == -1 {
+					continue
+				}
+
+				entryName := strings.TrimRight(string(entry.Name[:]), "\x00")
+				if entryName == "." || entryName == ".." {
+					continue
+				}
+
+				if err := fs.FreeInodeTree(entry.Inode); err != nil {
+					return fmt.Errorf("error liberando '%s': %w", entryName, err)
+				}
+			}
+		}
+	}
+
+	// Los bloques de carpeta y de punteros se liberan igual que los de un archivo
+	if err := fs.FreeFileInode(&inode); err != nil {
+		return err
+	}
+
+	if err := utilities.WriteObject(fs.File, inode, offset); err != nil {
+		return err
+	}
+
+	return fs.Sb.UpdateInodeBitmap(inodeIndex, [1]byte{'0'}, fs.File)
+}
+
+// Las copias quedan a nombre del usuario y de su grupo principal, GIDs[0]
+func (fs *FileSystem) CopyInodeTree(srcInodeIndex int32, srcPath string, destParentIndex int32, entryName string, UID int32, GIDs []int32, skipped *[]string) error {
+	var srcInode Inode
+	if err := utilities.ReadObject(fs.File, &srcInode, int64(fs.Sb.InodeStart+srcInodeIndex*fs.Sb.InodeSize)); err != nil {
+		return err
+	}
+
+	perm := PermRead
+	if srcInode.Type == [1]byte{'0'} {
+		perm |= PermExecute
+	}
+
+	if !fs.CheckPermission(&srcInode, UID, GIDs, perm) {
+		*skipped = append(*skipped, srcPath)
+		return nil
+	}
+
+	var destParentInode Inode
+	if err := utilities.ReadObject(fs.File, &destParentInode, int64(fs.Sb.InodeStart+destParentIndex*fs.Sb.InodeSize)); err != nil {
+		return err
+	}
+
+	if srcInode.Type != [1]byte{'0'} {
+		content, err := fs.ReadFileContent(&srcInode)
+		if err != nil {
+			return fmt.Errorf("error al leer '%s': %w", srcPath, err)
+		}
+
+		fileInodeIndex, err := fs.Sb.GetFreeInodeIndex(fs.File)
+		if err != nil {
+			return err
+		}
+
+		allocatedBlocks, err := fs.AllocateFileBlocks([]byte(content))
+		if err != nil {
+			return fmt.Errorf("error al asignar bloques para '%s': %w", srcPath, err)
+		}
+
+		fileInode := NewInode(UID, GIDs[0], int32(len(content)), [1]byte{'1'}, srcInode.Perm)
+		fileInode.Blocks = allocatedBlocks
+
+		fileInodeOffset := int64(fs.Sb.InodeStart + fileInodeIndex*fs.Sb.InodeSize)
+		if err := utilities.WriteObject(fs.File, *fileInode, fileInodeOffset); err != nil {
+			return err
+		}
+
+		if err := fs.Sb.UpdateInodeBitmap(fileInodeIndex, [1]byte{'1'}, fs.File); err != nil {
+			return err
+		}
+
+		return fs.AddEntryToParent(&destParentInode, destParentIndex, entryName, fileInodeIndex)
+	}
+
+	folderInodeIndex, err := fs.CreateNewFolder(destParentIndex, UID, GIDs[0])
+	if err != nil {
+		return err
+	}
+
+	var folderInode Inode
+	folderInodeOffset := int64(fs.Sb.InodeStart + folderInodeIndex*fs.Sb.InodeSize)
+	if err := utilities.ReadObject(fs.File, &folderInode, folderInodeOffset); err != nil {
+		return err
+	}
+	folderInode.Perm = srcInode.Perm
+	if err := utilities.WriteObject(fs.File, folderInode, folderInodeOffset); err != nil {
+		return err
+	}
+
+	if err := fs.AddEntryToParent(&destParentInode, destParentIndex, entryName, folderInodeIndex); err != nil {
+		return err
+	}
+
+	folderBlocks, err := fs.GetDataBlocks(&srcInode)
+	if err != nil {
+		return err
+	}
+
+	for _, blockIndex := range folderBlocks {
+		var folderBlock FolderBlock
+		if err := utilities.ReadObject(fs.File, &folderBlock, int64(fs.Sb.BlockStart+blockIndex*fs.Sb.BlockSize)); err != nil {
+			return err
+		}
+
+		for _, entry := range folderBlock.Content {
+			if entry.Inode == -1 {
+				continue
+			}
+
+			childName := strings.TrimRight(string(entry.Name[:]), "\x00")
+			if childName == "." || childName == ".." {
+				continue
+			}
+
+			if err := fs.CopyInodeTree(entry.Inode, path.Join(srcPath, childName), folderInodeIndex, childName, UID, GIDs, skipped); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+const RootUID int32 = 1
+
+const (
+	PermRead    byte = 4
+	PermWrite   byte = 2
+	PermExecute byte = 1
+)
+
+// Decide si el usuario tiene el permiso solicitado sobre el inodo según sus
+// bits UGO; se usan los bits de grupo si el inodo pertenece a cualquiera de
+// los grupos del usuario. El usuario root siempre tiene acceso.
+func (fs *FileSystem) CheckPermission(inode *Inode, UID int32, GIDs []int32, perm byte) bool {
+	if UID == RootUID {
+		return true
+	}
+
+	var bits byte
+	switch {
+	case inode.UID == UID:
+		bits = inode.Perm[0]
+	case slices.Contains(GIDs, inode.GID):
+		bits = inode.Perm[1]
+	default:
+		bits = inode.Perm[2]
+	}
+
+	return (bits-'0')&perm == perm
+}
+
+func (fs *FileSystem) RequirePermission(inode *Inode, entryPath string, UID int32, GIDs []int32, perm byte) error {
+	if fs.CheckPermission(inode, UID, GIDs, perm) {
+		return nil
+	}
+
+	var permNames []string
+	if perm&PermRead != 0 {
+		permNames = append(permNames, "lectura")
+	}
+	if perm&PermWrite != 0 {
+		permNames = append(permNames, "escritura")
+	}
+	if perm&PermExecute != 0 {
+		permNames = append(permNames, "ejecución")
+	}
+	permName := strings.Join(permNames, " y ")
+
+	return fmt.Errorf("permiso denegado: no tiene permiso de %s sobre '%s'", permName, entryPath)
 }
@@ -115,3 +115,12 @@ func (b *PointerBlock) GenerateTable(index int32) string {
 		>];
 	`, index, index, rows.String())
 }
+
+func (b *FolderBlock) IsEmpty() bool {
+	for _, entry := range b.Content {
+		if entry.Inode != -1 {
+			return false
+		}
+	}
+	return true
+}
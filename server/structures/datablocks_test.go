@@ -0,0 +1,116 @@
+package structures
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// Sistema de archivos recién formateado sobre un archivo temporal
+func newTestFileSystem(t *testing.T, size int32) *FileSystem {
+	t.Helper()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "disk.mia"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { file.Close() })
+
+	if err := file.Truncate(int64(size)); err != nil {
+		t.Fatal(err)
+	}
+
+	superBlock := NewSuperBlock(&Partition{Start: 0, Size: size}, 2)
+	if err := superBlock.InitializeBitMaps(file); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewFileSystem(file, superBlock)
+}
+
+func allocateTestBlocks(t *testing.T, fs *FileSystem, count int) []int32 {
+	t.Helper()
+
+	blocks := make([]int32, count)
+	for i := range blocks {
+		blockIndex, err := fs.Sb.GetFreeBlockIndex(fs.File)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.Sb.UpdateBlockBitmap(blockIndex, [1]byte{'1'}, fs.File); err != nil {
+			t.Fatal(err)
+		}
+		blocks[i] = blockIndex
+	}
+	return blocks
+}
+
+func TestSetDataBlocksRoundTrip(t *testing.T) {
+	pointerPerBlock := len(PointerBlock{}.Pointers)
+	simpleLimit := 12 + pointerPerBlock
+	doubleLimit := simpleLimit + pointerPerBlock*pointerPerBlock
+
+	counts := []int{0, 1, 12, 13, simpleLimit, simpleLimit + 1, simpleLimit + pointerPerBlock + 1, doubleLimit, doubleLimit + 1, doubleLimit + pointerPerBlock + 1}
+
+	for _, count := range counts {
+		fs := newTestFileSystem(t, 512*1024)
+		freeBefore := fs.Sb.FreeBlocksCount
+
+		dataBlocks := allocateTestBlocks(t, fs, count)
+		inode := NewInode(1, 1, 0, [1]byte{'1'}, [3]byte{'6', '6', '4'})
+
+		if err := fs.SetDataBlocks(inode, dataBlocks); err != nil {
+			t.Fatalf("SetDataBlocks(%d bloques): %v", count, err)
+		}
+
+		got, err := fs.GetDataBlocks(inode)
+		if err != nil {
+			t.Fatalf("GetDataBlocks(%d bloques): %v", count, err)
+		}
+		if !slices.Equal(got, dataBlocks) {
+			t.Errorf("GetDataBlocks(%d bloques) = %v, want %v", count, got, dataBlocks)
+		}
+
+		used := freeBefore - fs.Sb.FreeBlocksCount
+		if want := int32(count) + pointerBlocksFor(int32(count)); used != want {
+			t.Errorf("%d bloques de datos usan %d bloques en total, want %d", count, used, want)
+		}
+	}
+}
+
+// Al reducir y volver a crecer se reutilizan o liberan los bloques de punteros
+func TestSetDataBlocksResize(t *testing.T) {
+	pointerPerBlock := len(PointerBlock{}.Pointers)
+	fs := newTestFileSystem(t, 512*1024)
+	freeBefore := fs.Sb.FreeBlocksCount
+
+	dataBlocks := allocateTestBlocks(t, fs, 12+pointerPerBlock+pointerPerBlock*pointerPerBlock+3)
+	inode := NewInode(1, 1, 0, [1]byte{'1'}, [3]byte{'6', '6', '4'})
+
+	for _, count := range []int{len(dataBlocks), 20, 5, len(dataBlocks), 0} {
+		if err := fs.SetDataBlocks(inode, dataBlocks[:count]); err != nil {
+			t.Fatalf("SetDataBlocks(%d bloques): %v", count, err)
+		}
+
+		got, err := fs.GetDataBlocks(inode)
+		if err != nil {
+			t.Fatalf("GetDataBlocks(%d bloques): %v", count, err)
+		}
+		if !slices.Equal(got, dataBlocks[:count]) {
+			t.Errorf("GetDataBlocks(%d bloques) = %v, want %v", count, got, dataBlocks[:count])
+		}
+
+		// Los bloques de datos siguen reservados; solo cambian los de punteros
+		used := freeBefore - fs.Sb.FreeBlocksCount
+		if want := int32(len(dataBlocks)) + pointerBlocksFor(int32(count)); used != want {
+			t.Errorf("con %d bloques se usan %d bloques en total, want %d", count, used, want)
+		}
+	}
+
+	for i, block := range inode.Blocks {
+		if block != -1 {
+			t.Errorf("inode.Blocks[%d] = %d después de vaciar, want -1", i, block)
+		}
+	}
+}
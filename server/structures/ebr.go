@@ -2,6 +2,8 @@ package structures
 
 import (
 	"fmt"
+	"os"
+	"server/utilities"
 	"strings"
 )
 
@@ -28,6 +30,68 @@ func NewEBR(fit string, start int32, size int32, name string) *EBR {
 	}
 }
 
+// Representa la partición lógica como una partición para montarla igual que una primaria
+func (e *EBR) ToPartition() *Partition {
+	return &Partition{
+		Status:      e.PartMount,
+		Type:        [1]byte{'L'},
+		Fit:         e.PartFit,
+		Start:       e.PartStart,
+		Size:        e.PartSize,
+		Name:        e.PartName,
+		Correlative: -1,
+	}
+}
+
+type EBRInfo struct {
+	EBR      EBR
+	Position int32
+}
+
+// Recorre la cadena de EBRs desde el inicio de la extendida siguiendo PartNext.
+// Incluye el primer EBR aunque esté vacío porque ocupa espacio en la extendida.
+func ListEBRs(file *os.File, extendedStart int32) ([]EBRInfo, error) {
+	var ebrs []EBRInfo
+	position := extendedStart
+
+	for {
+		var ebr EBR
+		if err := utilities.ReadObject(file, &ebr, int64(position)); err != nil {
+			return nil, fmt.Errorf("error leyendo EBR en posición %d: %w", position, err)
+		}
+
+		if ebr.PartSize <= 0 && ebr.PartNext <= 0 && position == extendedStart {
+			return ebrs, nil
+		}
+
+		ebrs = append(ebrs, EBRInfo{EBR: ebr, Position: position})
+
+		if ebr.PartNext <= 0 {
+			return ebrs, nil
+		}
+		position = ebr.PartNext
+	}
+}
+
+// Busca en la cadena de EBRs la partición lógica con el nombre indicado.
+// Devuelve nil y -1 si no existe.
+func FindLogicalPartition(file *os.File, extendedStart int32, name string) (*EBR, int32, error) {
+	ebrs, err := ListEBRs(file, extendedStart)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	trimmed := strings.TrimSpace(name)
+	for i := range ebrs {
+		ebr := &ebrs[i].EBR
+		if ebr.PartSize > 0 && strings.Trim(string(ebr.PartName[:]), "\x00 ") == trimmed {
+			return ebr, ebrs[i].Position, nil
+		}
+	}
+
+	return nil, -1, nil
+}
+
 func (e *EBR) GenerateTable() string {
 	status := rune(e.PartMount[0])
 	fit := rune(e.PartFit[0])
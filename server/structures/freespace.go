@@ -0,0 +1,93 @@
+package structures
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+type FreeSpace struct {
+	Start int32 // Byte de inicio del espacio libre
+	Size  int32 // Tamaño del espacio libre
+}
+
+// Espacios libres del disco entre el MBR, las particiones y el final del disco
+func (m *MBR) FreeSpaces() []FreeSpace {
+	var spaces []FreeSpace
+	lastOffset := int32(binary.Size(*m))
+
+	for _, pInfo := range m.SortedPartitions() {
+		if pInfo.Partition.Start > lastOffset {
+			spaces = append(spaces, FreeSpace{Start: lastOffset, Size: pInfo.Partition.Start - lastOffset})
+		}
+		lastOffset = max(lastOffset, pInfo.Partition.Start+pInfo.Partition.Size)
+	}
+
+	if m.Size > lastOffset {
+		spaces = append(spaces, FreeSpace{Start: lastOffset, Size: m.Size - lastOffset})
+	}
+
+	return spaces
+}
+
+// Espacios libres de la extendida. Cada lógica ocupa su EBR más sus datos; el
+// primer EBR vacío no ocupa nada porque puede reutilizarse para una nueva lógica.
+func ExtendedFreeSpaces(extended *Partition, ebrs []EBRInfo) []FreeSpace {
+	ebrSize := int32(binary.Size(EBR{}))
+
+	used := make([]FreeSpace, 0, len(ebrs))
+	for _, info := range ebrs {
+		if info.EBR.PartSize <= 0 {
+			if info.Position != extended.Start {
+				used = append(used, FreeSpace{Start: info.Position, Size: ebrSize})
+			}
+			continue
+		}
+		used = append(used, FreeSpace{Start: info.Position, Size: info.EBR.PartStart + info.EBR.PartSize - info.Position})
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i].Start < used[j].Start })
+
+	var spaces []FreeSpace
+	lastOffset := extended.Start
+	end := extended.Start + extended.Size
+
+	for _, u := range used {
+		if u.Start > lastOffset {
+			spaces = append(spaces, FreeSpace{Start: lastOffset, Size: u.Start - lastOffset})
+		}
+		lastOffset = max(lastOffset, u.Start+u.Size)
+	}
+
+	if end > lastOffset {
+		spaces = append(spaces, FreeSpace{Start: lastOffset, Size: end - lastOffset})
+	}
+
+	return spaces
+}
+
+// Elige el espacio libre según el ajuste: 'F' primer ajuste, 'B' mejor ajuste
+// y 'W' peor ajuste. Devuelve false si ningún espacio alcanza.
+func ChooseFreeSpace(spaces []FreeSpace, size int32, fit byte) (FreeSpace, bool) {
+	var chosen FreeSpace
+	found := false
+
+	for _, space := range spaces {
+		if space.Size < size {
+			continue
+		}
+
+		switch {
+		case !found:
+			chosen, found = space, true
+		case fit == 'B' && space.Size < chosen.Size:
+			chosen = space
+		case fit == 'W' && space.Size > chosen.Size:
+			chosen = space
+		}
+
+		if fit == 'F' {
+			break
+		}
+	}
+
+	return chosen, found
+}
@@ -0,0 +1,127 @@
+package structures
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+func TestChooseFreeSpace(t *testing.T) {
+	spaces := []FreeSpace{
+		{Start: 100, Size: 50},
+		{Start: 200, Size: 300},
+		{Start: 600, Size: 80},
+		{Start: 800, Size: 500},
+	}
+
+	tests := []struct {
+		fit   byte
+		size  int32
+		want  FreeSpace
+		found bool
+	}{
+		{'F', 60, FreeSpace{Start: 200, Size: 300}, true},
+		{'B', 60, FreeSpace{Start: 600, Size: 80}, true},
+		{'W', 60, FreeSpace{Start: 800, Size: 500}, true},
+		{'F', 50, FreeSpace{Start: 100, Size: 50}, true},
+		{'B', 50, FreeSpace{Start: 100, Size: 50}, true},
+		{'B', 400, FreeSpace{Start: 800, Size: 500}, true},
+		{'W', 501, FreeSpace{}, false},
+	}
+
+	for _, test := range tests {
+		got, found := ChooseFreeSpace(spaces, test.size, test.fit)
+		if got != test.want || found != test.found {
+			t.Errorf("ChooseFreeSpace(%c, %d) = %v, %v; want %v, %v", test.fit, test.size, got, found, test.want, test.found)
+		}
+	}
+
+	if _, found := ChooseFreeSpace(nil, 1, 'F'); found {
+		t.Errorf("ChooseFreeSpace sin espacios encontró uno")
+	}
+}
+
+func TestMBRFreeSpaces(t *testing.T) {
+	mbrSize := int32(binary.Size(MBR{}))
+
+	mbr := MBR{Size: 2000}
+	if got, want := mbr.FreeSpaces(), []FreeSpace{{Start: mbrSize, Size: 2000 - mbrSize}}; !slices.Equal(got, want) {
+		t.Errorf("disco vacío: FreeSpaces() = %v, want %v", got, want)
+	}
+
+	// Las particiones no están ordenadas en el MBR y hay huecos entre ellas
+	mbr.Partitions[0] = Partition{Start: 1000, Size: 200}
+	mbr.Partitions[2] = Partition{Start: mbrSize, Size: 300}
+	mbr.Partitions[3] = Partition{Start: 1200, Size: 800}
+
+	want := []FreeSpace{{Start: mbrSize + 300, Size: 1000 - mbrSize - 300}}
+	if got := mbr.FreeSpaces(); !slices.Equal(got, want) {
+		t.Errorf("FreeSpaces() = %v, want %v", got, want)
+	}
+}
+
+func TestExtendedFreeSpaces(t *testing.T) {
+	ebrSize := int32(binary.Size(EBR{}))
+	extended := &Partition{Start: 1000, Size: 1000}
+
+	logical := func(position, size int32) EBRInfo {
+		return EBRInfo{EBR: EBR{PartStart: position + ebrSize, PartSize: size}, Position: position}
+	}
+
+	if got, want := ExtendedFreeSpaces(extended, nil), []FreeSpace{{Start: 1000, Size: 1000}}; !slices.Equal(got, want) {
+		t.Errorf("extendida vacía: ExtendedFreeSpaces() = %v, want %v", got, want)
+	}
+
+	ebrs := []EBRInfo{logical(1000, 100), logical(1000+ebrSize+100, 200)}
+	end := 1000 + 2*ebrSize + 300
+	want := []FreeSpace{{Start: end, Size: 2000 - end}}
+	if got := ExtendedFreeSpaces(extended, ebrs); !slices.Equal(got, want) {
+		t.Errorf("ExtendedFreeSpaces() = %v, want %v", got, want)
+	}
+}
+
+// Huecos que deja fdisk -delete dentro de la extendida
+func TestExtendedFreeSpacesAfterDelete(t *testing.T) {
+	ebrSize := int32(binary.Size(EBR{}))
+	extended := &Partition{Start: 1000, Size: 1000}
+
+	logical := func(position, size int32) EBRInfo {
+		return EBRInfo{EBR: EBR{PartStart: position + ebrSize, PartSize: size}, Position: position}
+	}
+
+	tests := []struct {
+		name string
+		ebrs []EBRInfo
+		want []FreeSpace
+	}{
+		{
+			// Al borrar la primera lógica su EBR queda vacío y reutilizable
+			name: "primera lógica eliminada",
+			ebrs: []EBRInfo{{Position: 1000}, logical(1400, 100)},
+			want: []FreeSpace{{Start: 1000, Size: 400}, {Start: 1400 + ebrSize + 100, Size: 600 - ebrSize - 100}},
+		},
+		{
+			// La lógica del medio sale de la cadena y deja su espacio libre
+			name: "lógica del medio eliminada",
+			ebrs: []EBRInfo{logical(1000, 100), logical(1600, 100)},
+			want: []FreeSpace{{Start: 1000 + ebrSize + 100, Size: 600 - ebrSize - 100}, {Start: 1600 + ebrSize + 100, Size: 400 - ebrSize - 100}},
+		},
+		{
+			// Un EBR vacío que no es el primero sigue ocupando su tamaño
+			name: "EBR vacío intermedio",
+			ebrs: []EBRInfo{logical(1000, 100), {Position: 1500}},
+			want: []FreeSpace{{Start: 1000 + ebrSize + 100, Size: 500 - ebrSize - 100}, {Start: 1500 + ebrSize, Size: 500 - ebrSize}},
+		},
+		{
+			name: "última lógica llega al final de la extendida",
+			ebrs: []EBRInfo{{Position: 1000}, logical(1500, 500-ebrSize)},
+			want: []FreeSpace{{Start: 1000, Size: 500}},
+		},
+	}
+
+	for _, test := range tests {
+		if got := ExtendedFreeSpaces(extended, test.ebrs); !slices.Equal(got, test.want) {
+			t.Errorf("%s: ExtendedFreeSpaces() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package structures
+
+import (
+	"fmt"
+	"server/utilities"
+	"strings"
+)
+
+type ConsistencyReport struct {
+	Problems    []string // Inconsistencias encontradas, en el orden del recorrido
+	InodeBitmap []byte   // Bitmap de inodos según lo alcanzable desde la raíz
+	BlockBitmap []byte   // Bitmap de bloques según lo que referencian los inodos
+	UsedInodes  int32
+	UsedBlocks  int32
+}
+
+type consistencyChecker struct {
+	fs          *FileSystem
+	report      *ConsistencyReport
+	blockOwners map[int32]int32 // Bloque -> inodo que lo referenció primero
+}
+
+// Recorre el árbol desde el inodo 0 y compara lo alcanzable con los bitmaps y
+// los contadores del superbloque. No modifica la partición.
+func (fs *FileSystem) CheckConsistency() (*ConsistencyReport, error) {
+	report := &ConsistencyReport{
+		InodeBitmap: []byte(strings.Repeat("0", int(fs.Sb.InodesCount))),
+		BlockBitmap: []byte(strings.Repeat("0", int(fs.Sb.BlocksCount))),
+	}
+	checker := &consistencyChecker{
+		fs:          fs,
+		report:      report,
+		blockOwners: make(map[int32]int32),
+	}
+
+	if fs.Sb.InodesCount <= 0 || fs.Sb.BlocksCount <= 0 {
+		return nil, fmt.Errorf("el superbloque no tiene inodos ni bloques")
+	}
+
+	if err := checker.checkInode(0, 0, "/"); err != nil {
+		return nil, err
+	}
+
+	inodeBitmap, err := utilities.ReadBytes(fs.File, int(fs.Sb.InodesCount), int64(fs.Sb.BmInodeStart))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer bitmap de inodos: %v", err)
+	}
+
+	blockBitmap, err := utilities.ReadBytes(fs.File, int(fs.Sb.BlocksCount), int64(fs.Sb.BmBlockStart))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer bitmap de bloques: %v", err)
+	}
+
+	for i, bit := range inodeBitmap {
+		switch {
+		case bit == '1' && report.InodeBitmap[i] == '0':
+			checker.problem("inodo %d huérfano: está marcado en el bitmap pero no es alcanzable desde la raíz", i)
+		case bit != '1' && report.InodeBitmap[i] == '1':
+			checker.problem("inodo %d en uso pero libre en el bitmap", i)
+		}
+	}
+
+	for i, bit := range blockBitmap {
+		switch {
+		case bit == '1' && report.BlockBitmap[i] == '0':
+			checker.problem("bloque %d huérfano: está marcado en el bitmap pero ningún inodo lo referencia", i)
+		case bit != '1' && report.BlockBitmap[i] == '1':
+			checker.problem("bloque %d en uso pero libre en el bitmap", i)
+		}
+	}
+
+	if free := fs.Sb.InodesCount - report.UsedInodes; fs.Sb.FreeInodesCount != free {
+		checker.problem("s_free_inodes_count es %d pero hay %d inodos libres", fs.Sb.FreeInodesCount, free)
+	}
+
+	if free := fs.Sb.BlocksCount - report.UsedBlocks; fs.Sb.FreeBlocksCount != free {
+		checker.problem("s_free_blocks_count es %d pero hay %d bloques libres", fs.Sb.FreeBlocksCount, free)
+	}
+
+	return report, nil
+}
+
+// Reescribe los bitmaps y los contadores del superbloque con lo calculado por
+// CheckConsistency. El superbloque queda actualizado en memoria.
+func (fs *FileSystem) RepairBitmaps(report *ConsistencyReport) error {
+	if err := utilities.WriteBytes(fs.File, report.InodeBitmap, int64(fs.Sb.BmInodeStart)); err != nil {
+		return fmt.Errorf("error al reparar bitmap de inodos: %v", err)
+	}
+
+	if err := utilities.WriteBytes(fs.File, report.BlockBitmap, int64(fs.Sb.BmBlockStart)); err != nil {
+		return fmt.Errorf("error al reparar bitmap de bloques: %v", err)
+	}
+
+	fs.Sb.FreeInodesCount = fs.Sb.InodesCount - report.UsedInodes
+	fs.Sb.FreeBlocksCount = fs.Sb.BlocksCount - report.UsedBlocks
+	fs.Sb.FirstIno = 0
+	fs.Sb.FirstBlo = 0
+	return nil
+}
+
+func (c *consistencyChecker) problem(format string, args ...any) {
+	c.report.Problems = append(c.report.Problems, fmt.Sprintf(format, args...))
+}
+
+func (c *consistencyChecker) checkInode(inodeIndex int32, parentIndex int32, entryPath string) error {
+	c.report.InodeBitmap[inodeIndex] = '1'
+	c.report.UsedInodes++
+
+	var inode Inode
+	offset := int64(c.fs.Sb.InodeStart) + int64(inodeIndex)*int64(c.fs.Sb.InodeSize)
+	if err := utilities.ReadObject(c.fs.File, &inode, offset); err != nil {
+		return fmt.Errorf("error al leer el inodo %d: %w", inodeIndex, err)
+	}
+
+	if inode.Type != [1]byte{'0'} && inode.Type != [1]byte{'1'} {
+		c.problem("'%s' (inodo %d) tiene un tipo desconocido", entryPath, inodeIndex)
+		return nil
+	}
+
+	dataBlocks, err := c.collectBlocks(&inode, inodeIndex, entryPath)
+	if err != nil {
+		return err
+	}
+
+	if inode.Type == [1]byte{'1'} {
+		expected := (max(inode.Size, 0) + c.fs.Sb.BlockSize - 1) / c.fs.Sb.BlockSize
+		if inode.Size < 0 || int32(len(dataBlocks)) != expected {
+			c.problem("'%s' (inodo %d) tiene tamaño %d pero usa %d bloques de datos", entryPath, inodeIndex, inode.Size, len(dataBlocks))
+		}
+		return nil
+	}
+
+	if entryPath == "/" {
+		entryPath = ""
+	}
+
+	for i, blockIndex := range dataBlocks {
+		var folderBlock FolderBlock
+		offset := int64(c.fs.Sb.BlockStart) + int64(blockIndex)*int64(c.fs.Sb.BlockSize)
+		if err := utilities.ReadObject(c.fs.File, &folderBlock, offset); err != nil {
+			return fmt.Errorf("error al leer el bloque %d: %w", blockIndex, err)
+		}
+
+		for j, content := range folderBlock.Content {
+			name := strings.Trim(string(content.Name[:]), "\x00 ")
+
+			// Las dos primeras entradas del primer bloque son '.' y '..'
+			if i == 0 && j < 2 {
+				expectedName, expectedInode := ".", inodeIndex
+				if j == 1 {
+					expectedName, expectedInode = "..", parentIndex
+				}
+
+				if name != expectedName || content.Inode != expectedInode {
+					c.problem("'%s/' (inodo %d) tiene la entrada '%s' -> %d, se esperaba '%s' -> %d", entryPath, inodeIndex, name, content.Inode, expectedName, expectedInode)
+				}
+				continue
+			}
+
+			if content.Inode == -1 {
+				continue
+			}
+
+			childPath := entryPath + "/" + name
+
+			if content.Inode < 0 || content.Inode >= c.fs.Sb.InodesCount {
+				c.problem("'%s' apunta al inodo fuera de rango %d", childPath, content.Inode)
+				continue
+			}
+
+			if name == "." || name == ".." {
+				c.problem("'%s/' (inodo %d) tiene una entrada '%s' fuera de su lugar", entryPath, inodeIndex, name)
+				continue
+			}
+
+			if c.report.InodeBitmap[content.Inode] == '1' {
+				c.problem("'%s' apunta al inodo %d, que ya está referenciado por otra entrada", childPath, content.Inode)
+				continue
+			}
+
+			if err := c.checkInode(content.Inode, inodeIndex, childPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marca los bloques del inodo, incluidos los de punteros, y devuelve sus
+// bloques de datos válidos en orden.
+func (c *consistencyChecker) collectBlocks(inode *Inode, inodeIndex int32, entryPath string) ([]int32, error) {
+	var dataBlocks []int32
+
+	claim := func(blockIndex int32) bool {
+		if blockIndex < 0 || blockIndex >= c.fs.Sb.BlocksCount {
+			c.problem("'%s' (inodo %d) tiene un puntero fuera de rango: %d", entryPath, inodeIndex, blockIndex)
+			return false
+		}
+
+		// El bloque se sigue leyendo para no reportar además un tamaño incorrecto
+		if owner, ok := c.blockOwners[blockIndex]; ok {
+			c.problem("bloque %d referenciado dos veces: por el inodo %d y por '%s' (inodo %d)", blockIndex, owner, entryPath, inodeIndex)
+			return true
+		}
+
+		c.blockOwners[blockIndex] = inodeIndex
+		c.report.BlockBitmap[blockIndex] = '1'
+		c.report.UsedBlocks++
+		return true
+	}
+
+	var collectRecursive func(level int, blockPtr int32) error
+	collectRecursive = func(level int, blockPtr int32) error {
+		if blockPtr == -1 || !claim(blockPtr) {
+			return nil
+		}
+
+		var pointerBlock PointerBlock
+		offset := int64(c.fs.Sb.BlockStart) + int64(blockPtr)*int64(c.fs.Sb.BlockSize)
+		if err := utilities.ReadObject(c.fs.File, &pointerBlock, offset); err != nil {
+			return fmt.Errorf("error al leer el bloque de punteros %d: %w", blockPtr, err)
+		}
+
+		for _, nextPtr := range pointerBlock.Pointers {
+			if nextPtr == -1 {
+				continue
+			}
+
+			if level == 1 {
+				if claim(nextPtr) {
+					dataBlocks = append(dataBlocks, nextPtr)
+				}
+			} else if err := collectRecursive(level-1, nextPtr); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for i := 0; i < 12; i++ {
+		if inode.Blocks[i] != -1 && claim(inode.Blocks[i]) {
+			dataBlocks = append(dataBlocks, inode.Blocks[i])
+		}
+	}
+
+	for level := 1; level <= 3; level++ {
+		if err := collectRecursive(level, inode.Blocks[11+level]); err != nil {
+			return nil, err
+		}
+	}
+
+	return dataBlocks, nil
+}
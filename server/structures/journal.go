@@ -0,0 +1,171 @@
+package structures
+
+import (
+	"encoding/binary"
+	"fmt"
+	"html"
+	"server/utilities"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type Journal struct {
+	Count   int32       // Número de la entrada, 0 si está libre
+	Content Information // Operación registrada
+}
+
+type Information struct {
+	Operation        [10]byte // Comando que modificó el sistema de archivos
+	Path             [64]byte // Ruta afectada
+	Content          [64]byte // Contenido o argumentos de la operación
+	Date             int64    // Fecha de la operación
+	UID              int32    // Usuario que realizó la operación
+	GID              int32    // Grupo principal del usuario
+	PathTruncated    bool     // La ruta no cupo completa en Path
+	ContentTruncated bool     // El contenido no cupo completo en Content
+}
+
+func NewJournal(count int32, operation string, path string, content string, UID int32, GID int32) *Journal {
+	journal := &Journal{
+		Count: count,
+		Content: Information{
+			Date: time.Now().Unix(),
+			UID:  UID,
+			GID:  GID,
+		},
+	}
+	copy(journal.Content.Operation[:], operation)
+	journal.Content.PathTruncated = copy(journal.Content.Path[:], path) < len(path)
+	journal.Content.ContentTruncated = copy(journal.Content.Content[:], content) < len(content)
+	return journal
+}
+
+func (j *Journal) GetOperation() string {
+	return strings.TrimRight(string(j.Content.Operation[:]), "\x00")
+}
+
+func (j *Journal) GetPath() string {
+	return strings.TrimRight(string(j.Content.Path[:]), "\x00")
+}
+
+func (j *Journal) GetContent() string {
+	return strings.TrimRight(string(j.Content.Content[:]), "\x00")
+}
+
+func (j *Journal) String() string {
+	date := time.Unix(j.Content.Date, 0).Format("2006-01-02 15:04:05")
+	return fmt.Sprintf("- Count: %d\n- Operation: %s\n- Path: %s\n- Content: %s\n- Date: %s\n",
+		j.Count, j.GetOperation(), j.GetPath(), j.GetContent(), date)
+}
+
+func JournalSize() int {
+	return binary.Size(Journal{})
+}
+
+// Devuelve las entradas registradas en el journal, en orden.
+func (fs *FileSystem) ReadJournal() ([]Journal, error) {
+	if !fs.Sb.IsExt3() {
+		return nil, fmt.Errorf("el sistema de archivos no es EXT3")
+	}
+
+	entries := make([]Journal, fs.Sb.InodesCount)
+	if err := utilities.ReadObject(fs.File, entries, int64(fs.Sb.JournalStart())); err != nil {
+		return nil, fmt.Errorf("error al leer el journal: %w", err)
+	}
+
+	for i, entry := range entries {
+		if entry.Count == 0 {
+			return entries[:i], nil
+		}
+	}
+
+	return entries, nil
+}
+
+// Los comandos lo revisan antes de modificar nada, para no aplicar una
+// operación que después no se podría registrar. En EXT2 no hace nada.
+func (fs *FileSystem) CheckJournalSpace() error {
+	if !fs.Sb.IsExt3() {
+		return nil
+	}
+
+	entries, err := fs.ReadJournal()
+	if err != nil {
+		return err
+	}
+
+	if int32(len(entries)) >= fs.Sb.InodesCount {
+		return fmt.Errorf("el journal está lleno: no se pueden registrar más operaciones")
+	}
+
+	return nil
+}
+
+// Registra una operación ya aplicada junto con el usuario que la hizo. En EXT2
+// no hace nada.
+func (fs *FileSystem) AppendJournal(operation string, path string, content string, UID int32, GID int32) error {
+	if !fs.Sb.IsExt3() {
+		return nil
+	}
+
+	entries, err := fs.ReadJournal()
+	if err != nil {
+		return err
+	}
+
+	if int32(len(entries)) >= fs.Sb.InodesCount {
+		return fmt.Errorf("el journal está lleno: no se puede registrar la operación %s", operation)
+	}
+
+	journal := NewJournal(int32(len(entries))+1, operation, path, content, UID, GID)
+	offset := int64(fs.Sb.JournalStart()) + int64(len(entries))*int64(JournalSize())
+	if err := utilities.WriteObject(fs.File, *journal, offset); err != nil {
+		return fmt.Errorf("error al escribir en el journal: %w", err)
+	}
+
+	return nil
+}
+
+func GenerateJournalTable(entries []Journal) string {
+	var sb strings.Builder
+	sb.WriteString(`digraph G {node [shape=plaintext]; table [label=<
+	<table border="0" cellborder="1" cellspacing="0">
+	<tr><td colspan="5" bgcolor="#ad63caff"><b>Reporte Journaling</b></td></tr>
+	<tr><td><b>#</b></td><td><b>Operación</b></td><td><b>Ruta</b></td><td><b>Contenido</b></td><td><b>Fecha</b></td></tr>`)
+
+	for i, entry := range entries {
+		bgcolor := "#ffffffff"
+		if i%2 == 1 {
+			bgcolor = "#edceffff"
+		}
+
+		sb.WriteString(fmt.Sprintf(`
+		<tr><td bgcolor="%s">%d</td><td bgcolor="%s">%s</td><td bgcolor="%s">%s</td><td bgcolor="%s">%s</td><td bgcolor="%s">%s</td></tr>`,
+			bgcolor, entry.Count,
+			bgcolor, html.EscapeString(entry.GetOperation()),
+			bgcolor, html.EscapeString(entry.GetPath()),
+			bgcolor, html.EscapeString(printableContent(entry.GetContent())),
+			bgcolor, time.Unix(entry.Content.Date, 0).Format("2006-01-02 15:04:05"),
+		))
+	}
+
+	if len(entries) == 0 {
+		sb.WriteString(`
+		<tr><td colspan="5">El journal no tiene entradas</td></tr>`)
+	}
+
+	sb.WriteString("</table>>];}")
+	return sb.String()
+}
+
+// El contenido puede traer saltos de línea o quedar cortado a mitad de un carácter
+func printableContent(content string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToValidUTF8(content, "") {
+		if unicode.IsPrint(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
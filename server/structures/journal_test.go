@@ -0,0 +1,68 @@
+package structures
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestExt3FileSystem(t *testing.T, size int32) *FileSystem {
+	t.Helper()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "disk.mia"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { file.Close() })
+
+	if err := file.Truncate(int64(size)); err != nil {
+		t.Fatal(err)
+	}
+
+	superBlock := NewSuperBlock(&Partition{Start: 0, Size: size}, 3)
+	if err := superBlock.InitializeJournal(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := superBlock.InitializeBitMaps(file); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewFileSystem(file, superBlock)
+}
+
+func TestJournalSpace(t *testing.T) {
+	fs := newTestExt3FileSystem(t, 32*1024)
+
+	for i := int32(0); i < fs.Sb.InodesCount; i++ {
+		if err := fs.CheckJournalSpace(); err != nil {
+			t.Fatalf("CheckJournalSpace() con %d entradas: %v", i, err)
+		}
+		if err := fs.AppendJournal("mkdir", "/a", "", 1, 1); err != nil {
+			t.Fatalf("AppendJournal() con %d entradas: %v", i, err)
+		}
+	}
+
+	entries, err := fs.ReadJournal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int32(len(entries)) != fs.Sb.InodesCount || entries[len(entries)-1].Count != fs.Sb.InodesCount {
+		t.Fatalf("ReadJournal() devolvió %d entradas, want %d", len(entries), fs.Sb.InodesCount)
+	}
+
+	if err := fs.CheckJournalSpace(); err == nil {
+		t.Errorf("CheckJournalSpace() no detectó el journal lleno")
+	}
+	if err := fs.AppendJournal("mkdir", "/b", "", 1, 1); err == nil {
+		t.Errorf("AppendJournal() escribió en un journal lleno")
+	}
+}
+
+// En EXT2 no hay journal que revisar
+func TestJournalSpaceExt2(t *testing.T) {
+	fs := newTestFileSystem(t, 32*1024)
+
+	if err := fs.CheckJournalSpace(); err != nil {
+		t.Errorf("CheckJournalSpace() en EXT2 = %v, want nil", err)
+	}
+}
@@ -30,19 +30,20 @@ func NewMBR(size int, fit string) *MBR {
 	}
 }
 
+// Ubica la partición en un espacio libre del disco según el ajuste del disco
 func (m *MBR) AddPartition(typePart string, fit string, size int, name string) error {
-	offset := binary.Size(*m)
-
 	for i := range m.Partitions {
-		if m.Partitions[i].Size == 0 {
-			if offset+size > int(m.Size) {
-				return fmt.Errorf("no hay suficiente espacio en el disco para crear la partición '%s'", name)
-			}
-			m.Partitions[i].SetData(typePart, fit, offset, size, name)
-			return nil
-		} else {
-			offset += int(m.Partitions[i].Size)
+		if m.Partitions[i].Size != 0 {
+			continue
 		}
+
+		space, found := ChooseFreeSpace(m.FreeSpaces(), int32(size), m.DiskFit[0])
+		if !found {
+			return fmt.Errorf("no hay suficiente espacio en el disco para crear la partición '%s'", name)
+		}
+
+		m.Partitions[i].SetData(typePart, fit, int(space.Start), size, name)
+		return nil
 	}
 
 	return fmt.Errorf("no se encontró espacio disponible para crear la partición '%s'", name)
@@ -138,6 +139,35 @@ type PartitionInfo struct {
 	Index     int
 }
 
+// Devuelve las particiones asignadas ordenadas por su byte de inicio en el disco
+func (m *MBR) SortedPartitions() []PartitionInfo {
+	validPartitions := []PartitionInfo{}
+	for i, part := range m.Partitions {
+		if part.Size > 0 {
+			validPartitions = append(validPartitions, PartitionInfo{Partition: part, Index: i})
+		}
+	}
+	sort.Slice(validPartitions, func(i, j int) bool {
+		return validPartitions[i].Partition.Start < validPartitions[j].Partition.Start
+	})
+	return validPartitions
+}
+
+// Espacio libre entre el final de la partición y la siguiente (o el final del disco)
+func (m *MBR) FreeSpaceAfter(partition *Partition) int32 {
+	end := partition.Start + partition.Size
+	nextStart := m.Size
+
+	for _, pInfo := range m.SortedPartitions() {
+		if pInfo.Partition.Start >= end {
+			nextStart = pInfo.Partition.Start
+			break
+		}
+	}
+
+	return nextStart - end
+}
+
 func (m *MBR) GenerateDiskLayoutDOT(file *os.File) (string, error) {
 	var sb strings.Builder
 	totalSize := m.Size
@@ -154,17 +184,7 @@ func (m *MBR) GenerateDiskLayoutDOT(file *os.File) (string, error) {
 	sb.WriteString(fmt.Sprintf(`<td bgcolor="gray" align="center"><b>MBR</b><br/>%d bytes<br/>(%.2f%%)</td>`, mbrStructSize, mbrPercentage))
 	lastOffset := int64(mbrStructSize)
 
-	validPartitions := []PartitionInfo{}
-	for i, part := range m.Partitions {
-		if part.Size > 0 {
-			validPartitions = append(validPartitions, PartitionInfo{Partition: part, Index: i})
-		}
-	}
-	sort.Slice(validPartitions, func(i, j int) bool {
-		return validPartitions[i].Partition.Start < validPartitions[j].Partition.Start
-	})
-
-	for _, pInfo := range validPartitions {
+	for _, pInfo := range m.SortedPartitions() {
 		part := pInfo.Partition
 		freeSpaceBefore := int64(part.Start) - lastOffset
 		if freeSpaceBefore > 0 {
@@ -197,6 +217,12 @@ func (m *MBR) GenerateDiskLayoutDOT(file *os.File) (string, error) {
 					break
 				}
 
+				// Espacio libre que dejó una lógica eliminada antes de este EBR
+				if freeSpaceBetween := currentEbrOffset - lastElementEndInE; freeSpaceBetween > 0 {
+					freeExtPercentage := float64(freeSpaceBetween) / float64(part.Size) * 100
+					sb.WriteString(fmt.Sprintf(`<td bgcolor="#D3D3D3" align="center"><b>Libre Ext.</b><br/>%d bytes<br/>(%.2f%%)</td>`, freeSpaceBetween, freeExtPercentage))
+				}
+
 				ebrPercentage := float64(ebrStructSize) / float64(part.Size) * 100
 				sb.WriteString(fmt.Sprintf(`<td bgcolor="gray" align="center"><b>EBR</b><br/>%d bytes<br/>(%.2f%%)</td>`, ebrStructSize, ebrPercentage))
 				lastElementEndInE = currentEbrOffset + ebrStructSize
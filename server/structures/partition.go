@@ -69,12 +69,11 @@ func (p *Partition) GenerateTable(file *os.File, i int) (string, error) {
 				return "", fmt.Errorf("error leyendo EBR: %v", err)
 			}
 
-			if ebr.PartSize == 0 {
-				break
+			// Un primer EBR vacío solo enlaza con el resto de lógicas
+			if ebr.PartSize > 0 {
+				sb.WriteString(ebr.GenerateTable())
 			}
 
-			sb.WriteString(ebr.GenerateTable())
-
 			if ebr.PartNext <= 0 {
 				break
 			}
@@ -0,0 +1,89 @@
+package structures
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Las contraseñas se guardan en users.txt como $v1$<sal>$<hash>, con
+// PBKDF2-SHA256. Las iteraciones quedan muy por debajo de lo que recomienda
+// OWASP porque login, mkusr y passwd derivan la clave con el lock de la
+// partición tomado, y el resto de comandos sobre ella esperan mientras tanto.
+const (
+	passwordVersion    = "$v1$"
+	passwordSaltSize   = 16
+	passwordHashSize   = 32
+	passwordIterations = 10000
+)
+
+// Contraseña de una cuenta bloqueada: no coincide con ninguna entrada. La usa
+// recovery cuando no puede recuperar la contraseña de un usuario.
+const LockedPassword = "!"
+
+var passwordEncoding = base64.RawURLEncoding
+
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("error al generar la sal de la contraseña: %w", err)
+	}
+
+	hash, err := derivePassword(password, salt)
+	if err != nil {
+		return "", err
+	}
+
+	return passwordVersion + passwordEncoding.EncodeToString(salt) + "$" + passwordEncoding.EncodeToString(hash), nil
+}
+
+// Las líneas anteriores al hash guardan la contraseña en texto plano
+func IsLegacyPassword(stored string) bool {
+	return stored != LockedPassword && !strings.HasPrefix(stored, passwordVersion)
+}
+
+// Compara en tiempo constante y distinguiendo mayúsculas, tanto contra el hash
+// como contra una contraseña antigua en texto plano.
+func VerifyPassword(stored string, password string) bool {
+	if stored == LockedPassword {
+		return false
+	}
+
+	if IsLegacyPassword(stored) {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+	}
+
+	saltText, hashText, ok := strings.Cut(strings.TrimPrefix(stored, passwordVersion), "$")
+	if !ok {
+		return false
+	}
+
+	salt, err := passwordEncoding.DecodeString(saltText)
+	if err != nil {
+		return false
+	}
+
+	expected, err := passwordEncoding.DecodeString(hashText)
+	if err != nil {
+		return false
+	}
+
+	hash, err := derivePassword(password, salt)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(hash, expected) == 1
+}
+
+func derivePassword(password string, salt []byte) ([]byte, error) {
+	hash, err := pbkdf2.Key(sha256.New, password, salt, passwordIterations, passwordHashSize)
+	if err != nil {
+		return nil, fmt.Errorf("error al derivar la contraseña: %w", err)
+	}
+	return hash, nil
+}
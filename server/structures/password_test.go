@@ -0,0 +1,84 @@
+package structures
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashPasswordRoundTrip(t *testing.T) {
+	stored, err := HashPassword("Secreta123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(stored, passwordVersion) || IsLegacyPassword(stored) {
+		t.Fatalf("HashPassword() = %q, want prefijo %q", stored, passwordVersion)
+	}
+
+	if !VerifyPassword(stored, "Secreta123") {
+		t.Errorf("VerifyPassword rechazó la contraseña correcta")
+	}
+
+	for _, wrong := range []string{"secreta123", "Secreta1234", "", "Secreta12"} {
+		if VerifyPassword(stored, wrong) {
+			t.Errorf("VerifyPassword aceptó %q", wrong)
+		}
+	}
+}
+
+// Cada hash usa una sal distinta
+func TestHashPasswordSalt(t *testing.T) {
+	first, err := HashPassword("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := HashPassword("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first == second {
+		t.Errorf("dos hashes de la misma contraseña son iguales: %q", first)
+	}
+}
+
+func TestVerifyLegacyPassword(t *testing.T) {
+	if !IsLegacyPassword("123") {
+		t.Errorf("IsLegacyPassword(%q) = false, want true", "123")
+	}
+
+	if !VerifyPassword("123", "123") {
+		t.Errorf("VerifyPassword rechazó una contraseña antigua correcta")
+	}
+
+	if VerifyPassword("Abc", "abc") {
+		t.Errorf("VerifyPassword no distingue mayúsculas en contraseñas antiguas")
+	}
+}
+
+func TestVerifyLockedPassword(t *testing.T) {
+	if IsLegacyPassword(LockedPassword) {
+		t.Errorf("IsLegacyPassword(LockedPassword) = true, want false")
+	}
+
+	for _, password := range []string{LockedPassword, ""} {
+		if VerifyPassword(LockedPassword, password) {
+			t.Errorf("una cuenta bloqueada aceptó %q", password)
+		}
+	}
+}
+
+func TestVerifyMalformedPassword(t *testing.T) {
+	for _, stored := range []string{
+		passwordVersion,
+		passwordVersion + "sinhash",
+		passwordVersion + "!!!$AAAA",
+		passwordVersion + "AAAA$!!!",
+		passwordVersion + "$",
+	} {
+		if VerifyPassword(stored, "") || VerifyPassword(stored, "123") {
+			t.Errorf("VerifyPassword aceptó el valor mal formado %q", stored)
+		}
+	}
+}
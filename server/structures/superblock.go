@@ -30,15 +30,22 @@ type SuperBlock struct {
 	BlockStart      int32 // inicio de la tabla de bloques
 }
 
-func NewSuperBlock(partition *Partition) *SuperBlock {
+func NewSuperBlock(partition *Partition, filesystemType int32) *SuperBlock {
 	superBlockSize := binary.Size(SuperBlock{})
 	inodeSize := binary.Size(Inode{})
 	blockSize := binary.Size(FileBlock{})
-	n := CalculateStructureCount(partition.Size, superBlockSize, inodeSize, blockSize)
-	bmInodeStart, bmBlockStart, inodeStart, blockStart := calculateSuperBlockOffsets(partition.Start, n, superBlockSize, inodeSize)
+
+	// EXT3 reserva una entrada de journal por cada inodo justo después del superbloque
+	journalSize := 0
+	if filesystemType == 3 {
+		journalSize = JournalSize()
+	}
+
+	n := CalculateStructureCount(partition.Size, superBlockSize, journalSize, inodeSize, blockSize)
+	bmInodeStart, bmBlockStart, inodeStart, blockStart := calculateSuperBlockOffsets(partition.Start, n, superBlockSize, journalSize, inodeSize)
 
 	return &SuperBlock{
-		FilesystemType:  2,
+		FilesystemType:  filesystemType,
 		InodesCount:     n,
 		BlocksCount:     3 * n,
 		FreeInodesCount: n,
@@ -80,14 +87,40 @@ func (s *SuperBlock) InitializeBitMaps(file *os.File) error {
 	return nil
 }
 
-func CalculateStructureCount(partitionSize int32, superBlockSize, inodeSize, blockSize int) int32 {
+func (s *SuperBlock) IsExt3() bool {
+	return s.FilesystemType == 3
+}
+
+// El journal ocupa una entrada por inodo entre el superbloque y el bitmap de inodos
+func (s *SuperBlock) JournalStart() int32 {
+	return s.BmInodeStart - s.InodesCount*int32(JournalSize())
+}
+
+func (s *SuperBlock) InitializeJournal(file *os.File) error {
+	if !s.IsExt3() {
+		return nil
+	}
+
+	if err := utilities.WriteZeros(file, int64(s.JournalStart()), int64(s.InodesCount)*int64(JournalSize())); err != nil {
+		return fmt.Errorf("error al crear el journal: %v", err)
+	}
+
+	return nil
+}
+
+// Byte donde termina el área de bloques, último elemento del sistema de archivos
+func (s *SuperBlock) LayoutEnd() int32 {
+	return s.BlockStart + s.BlocksCount*s.BlockSize
+}
+
+func CalculateStructureCount(partitionSize int32, superBlockSize, journalSize, inodeSize, blockSize int) int32 {
 	numerator := int(partitionSize) - superBlockSize
-	denominator := 4 + inodeSize + 3*blockSize
+	denominator := journalSize + 4 + inodeSize + 3*blockSize
 	return int32(math.Floor(float64(numerator) / float64(denominator)))
 }
 
-func calculateSuperBlockOffsets(partitionStart, n int32, superBlockSize, inodeSize int) (bmInodeStart, bmBlockStart, inodeStart, blockStart int32) {
-	bmInodeStart = partitionStart + int32(superBlockSize)
+func calculateSuperBlockOffsets(partitionStart, n int32, superBlockSize, journalSize, inodeSize int) (bmInodeStart, bmBlockStart, inodeStart, blockStart int32) {
+	bmInodeStart = partitionStart + int32(superBlockSize) + n*int32(journalSize)
 	bmBlockStart = bmInodeStart + n
 	inodeStart = bmBlockStart + (3 * n)
 	blockStart = inodeStart + (int32(inodeSize) * n)
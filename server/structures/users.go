@@ -0,0 +1,388 @@
+package structures
+
+import (
+	"fmt"
+	"server/utilities"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const UsersFilePath = "/users.txt"
+
+type Group struct {
+	GID  int32 // 0 si el grupo fue eliminado
+	Name string
+}
+
+type User struct {
+	UID      int32    // 0 si el usuario fue eliminado
+	Groups   []string // El primero es el grupo principal
+	Name     string
+	Password string // Hash $v1$ o texto plano de versiones anteriores
+}
+
+// Cada línea de users.txt es un grupo o un usuario; se guardan en el orden del
+// archivo para volver a escribirlo igual.
+type usersEntry struct {
+	group *Group
+	user  *User
+}
+
+// Usuarios y grupos de /users.txt. Todos los comandos leen y modifican el
+// archivo a través de este tipo; los nombres no distinguen mayúsculas.
+type UsersFile struct {
+	fs         *FileSystem
+	inode      *Inode
+	inodeIndex int32
+	entries    []usersEntry
+}
+
+func (fs *FileSystem) LoadUsers() (*UsersFile, error) {
+	inode, inodeIndex, err := fs.GetInodeByPath(UsersFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("el archivo de usuarios no existe: %w", err)
+	}
+
+	content, err := fs.ReadFileContent(inode)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := parseUsers(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsersFile{
+		fs:         fs,
+		inode:      inode,
+		inodeIndex: inodeIndex,
+		entries:    entries,
+	}, nil
+}
+
+func parseUsers(content string) ([]usersEntry, error) {
+	var entries []usersEntry
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" {
+			continue
+		}
+
+		fields := strings.Split(trimmedLine, ",")
+		for j := range fields {
+			fields[j] = strings.TrimSpace(fields[j])
+		}
+
+		malformed := fmt.Errorf("línea %d de %s mal formada: '%s'", i+1, UsersFilePath, trimmedLine)
+
+		if len(fields) < 3 {
+			return nil, malformed
+		}
+
+		id, err := strconv.ParseInt(fields[0], 10, 32)
+		if err != nil || id < 0 {
+			return nil, malformed
+		}
+
+		switch {
+		case fields[1] == "G" && len(fields) == 3 && fields[2] != "":
+			entries = append(entries, usersEntry{group: &Group{GID: int32(id), Name: fields[2]}})
+		case fields[1] == "U" && len(fields) == 5 && fields[3] != "":
+			groups := strings.Split(fields[2], ";")
+			if slices.Contains(groups, "") {
+				return nil, malformed
+			}
+			entries = append(entries, usersEntry{user: &User{UID: int32(id), Groups: groups, Name: fields[3], Password: fields[4]}})
+		default:
+			return nil, malformed
+		}
+	}
+
+	return entries, nil
+}
+
+func (u *UsersFile) String() string {
+	var sb strings.Builder
+	for _, entry := range u.entries {
+		if entry.group != nil {
+			sb.WriteString(fmt.Sprintf("%d,G,%s\n", entry.group.GID, entry.group.Name))
+		} else {
+			sb.WriteString(fmt.Sprintf("%d,U,%s,%s,%s\n", entry.user.UID, strings.Join(entry.user.Groups, ";"), entry.user.Name, entry.user.Password))
+		}
+	}
+	return sb.String()
+}
+
+// Escribe el archivo en bloques nuevos y solo después libera los anteriores,
+// así un error a mitad de camino no deja /users.txt incompleto.
+func (u *UsersFile) Save() error {
+	content := []byte(u.String())
+	oldInode := *u.inode
+
+	allocatedBlocks, err := u.fs.AllocateFileBlocks(content)
+	if err != nil {
+		return fmt.Errorf("error al asignar bloques para el nuevo contenido de %s: %w", UsersFilePath, err)
+	}
+
+	u.inode.Blocks = allocatedBlocks
+	u.inode.Size = int32(len(content))
+	u.inode.UpdateAccessTime()
+	u.inode.UpdateModificationTime()
+
+	offset := int64(u.fs.Sb.InodeStart) + int64(u.inodeIndex)*int64(u.fs.Sb.InodeSize)
+	if err := utilities.WriteObject(u.fs.File, *u.inode, offset); err != nil {
+		return err
+	}
+
+	return u.fs.FreeFileInode(&oldInode)
+}
+
+// Grupos activos, en el orden del archivo
+func (u *UsersFile) Groups() []*Group {
+	var groups []*Group
+	for _, entry := range u.entries {
+		if entry.group != nil && entry.group.GID != 0 {
+			groups = append(groups, entry.group)
+		}
+	}
+	return groups
+}
+
+// Usuarios activos, en el orden del archivo
+func (u *UsersFile) Users() []*User {
+	var users []*User
+	for _, entry := range u.entries {
+		if entry.user != nil && entry.user.UID != 0 {
+			users = append(users, entry.user)
+		}
+	}
+	return users
+}
+
+func (u *UsersFile) FindGroup(name string) *Group {
+	for _, group := range u.Groups() {
+		if strings.EqualFold(group.Name, name) {
+			return group
+		}
+	}
+	return nil
+}
+
+func (u *UsersFile) FindUser(name string) *User {
+	for _, user := range u.Users() {
+		if strings.EqualFold(user.Name, name) {
+			return user
+		}
+	}
+	return nil
+}
+
+func (u *UsersFile) FindGroupByGID(gid int32) *Group {
+	for _, group := range u.Groups() {
+		if group.GID == gid {
+			return group
+		}
+	}
+	return nil
+}
+
+func (u *UsersFile) FindUserByUID(uid int32) *User {
+	for _, user := range u.Users() {
+		if user.UID == uid {
+			return user
+		}
+	}
+	return nil
+}
+
+func (u *UsersFile) AddGroup(name string) (*Group, error) {
+	if err := validateUsersName(name); err != nil {
+		return nil, err
+	}
+
+	if u.FindGroup(name) != nil {
+		return nil, fmt.Errorf("el grupo '%s' ya existe", name)
+	}
+
+	var highestGid int32
+	for _, group := range u.Groups() {
+		highestGid = max(highestGid, group.GID)
+	}
+
+	group := &Group{GID: highestGid + 1, Name: name}
+	u.entries = append(u.entries, usersEntry{group: group})
+	return group, nil
+}
+
+func (u *UsersFile) RemoveGroup(name string) error {
+	group := u.FindGroup(name)
+	if group == nil {
+		return fmt.Errorf("el grupo '%s' no existe o ya fue eliminado", name)
+	}
+
+	// Un usuario sin grupo principal ya no podría iniciar sesión
+	var primaryOf []string
+	for _, user := range u.Users() {
+		if strings.EqualFold(user.Groups[0], group.Name) {
+			primaryOf = append(primaryOf, user.Name)
+		}
+	}
+	if len(primaryOf) > 0 {
+		return fmt.Errorf("el grupo '%s' es el grupo principal de: %s; use chgrp antes de eliminarlo", group.Name, strings.Join(primaryOf, ", "))
+	}
+
+	group.GID = 0
+
+	// Los usuarios dejan de tenerlo como grupo secundario
+	for _, user := range u.Users() {
+		user.Groups = append(user.Groups[:1], slices.DeleteFunc(user.Groups[1:], func(name string) bool {
+			return strings.EqualFold(name, group.Name)
+		})...)
+	}
+	return nil
+}
+
+// Agrega un usuario con la contraseña ya procesada para users.txt
+func (u *UsersFile) AddUser(name string, groupName string, storedPassword string) (*User, error) {
+	if err := validateUsersName(name); err != nil {
+		return nil, err
+	}
+
+	group := u.FindGroup(groupName)
+	if group == nil {
+		return nil, fmt.Errorf("el grupo '%s' no existe", groupName)
+	}
+
+	if u.FindUser(name) != nil {
+		return nil, fmt.Errorf("el usuario '%s' ya existe", name)
+	}
+
+	var highestUid int32
+	for _, user := range u.Users() {
+		highestUid = max(highestUid, user.UID)
+	}
+
+	user := &User{UID: highestUid + 1, Groups: []string{group.Name}, Name: name, Password: storedPassword}
+	u.entries = append(u.entries, usersEntry{user: user})
+	return user, nil
+}
+
+func (u *UsersFile) RemoveUser(name string) error {
+	user := u.FindUser(name)
+	if user == nil {
+		return fmt.Errorf("el usuario '%s' no existe o ya fue eliminado", name)
+	}
+
+	user.UID = 0
+	return nil
+}
+
+func (u *UsersFile) SetUserGroup(name string, groupName string) error {
+	user := u.FindUser(name)
+	if user == nil {
+		return fmt.Errorf("el usuario '%s' no existe o ya fue eliminado", name)
+	}
+
+	group := u.FindGroup(groupName)
+	if group == nil {
+		return fmt.Errorf("el grupo '%s' no existe o ya fue eliminado", groupName)
+	}
+
+	// El nuevo grupo principal deja de figurar como secundario
+	user.Groups = append([]string{group.Name}, slices.DeleteFunc(user.Groups[1:], func(name string) bool {
+		return strings.EqualFold(name, group.Name)
+	})...)
+	return nil
+}
+
+func (u *UsersFile) AddUserToGroup(name string, groupName string) error {
+	user := u.FindUser(name)
+	if user == nil {
+		return fmt.Errorf("el usuario '%s' no existe o ya fue eliminado", name)
+	}
+
+	group := u.FindGroup(groupName)
+	if group == nil {
+		return fmt.Errorf("el grupo '%s' no existe o ya fue eliminado", groupName)
+	}
+
+	if user.InGroup(group.Name) {
+		return fmt.Errorf("el usuario '%s' ya pertenece al grupo '%s'", user.Name, group.Name)
+	}
+
+	user.Groups = append(user.Groups, group.Name)
+	return nil
+}
+
+func (u *UsersFile) RemoveUserFromGroup(name string, groupName string) error {
+	user := u.FindUser(name)
+	if user == nil {
+		return fmt.Errorf("el usuario '%s' no existe o ya fue eliminado", name)
+	}
+
+	if strings.EqualFold(user.Groups[0], groupName) {
+		return fmt.Errorf("'%s' es el grupo principal de '%s': use chgrp para cambiarlo", user.Groups[0], user.Name)
+	}
+
+	if !user.InGroup(groupName) {
+		return fmt.Errorf("el usuario '%s' no pertenece al grupo '%s'", user.Name, groupName)
+	}
+
+	user.Groups = append(user.Groups[:1], slices.DeleteFunc(user.Groups[1:], func(name string) bool {
+		return strings.EqualFold(name, groupName)
+	})...)
+	return nil
+}
+
+// GIDs de los grupos activos del usuario, empezando por el principal
+func (u *UsersFile) UserGroupIDs(user *User) []int32 {
+	var gids []int32
+	for _, name := range user.Groups {
+		if group := u.FindGroup(name); group != nil && !slices.Contains(gids, group.GID) {
+			gids = append(gids, group.GID)
+		}
+	}
+	return gids
+}
+
+func (user *User) InGroup(groupName string) bool {
+	return slices.ContainsFunc(user.Groups, func(name string) bool {
+		return strings.EqualFold(name, groupName)
+	})
+}
+
+// Cambia la contraseña del usuario por la ya procesada para users.txt
+func (u *UsersFile) SetUserPassword(name string, storedPassword string) error {
+	user := u.FindUser(name)
+	if user == nil {
+		return fmt.Errorf("el usuario '%s' no existe o ya fue eliminado", name)
+	}
+
+	user.Password = storedPassword
+	return nil
+}
+
+// Devuelve el usuario y su grupo principal si la contraseña es correcta
+func (u *UsersFile) Authenticate(name string, password string) (*User, *Group, error) {
+	user := u.FindUser(name)
+	if user == nil || !VerifyPassword(user.Password, password) {
+		return nil, nil, fmt.Errorf("usuario o contraseña incorrectos")
+	}
+
+	group := u.FindGroup(user.Groups[0])
+	if group == nil {
+		return nil, nil, fmt.Errorf("el grupo '%s' asignado al usuario '%s' no fue encontrado", user.Groups[0], user.Name)
+	}
+
+	return user, group, nil
+}
+
+func validateUsersName(name string) error {
+	if name == "" || strings.ContainsAny(name, ",; \t\r\n") {
+		return fmt.Errorf("el nombre '%s' no es válido para %s", name, UsersFilePath)
+	}
+	return nil
+}
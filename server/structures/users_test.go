@@ -0,0 +1,357 @@
+package structures
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseUsers(t *testing.T) {
+	content := "1,G,root\n1,U,root,root,123\n\n 2 , G , equipo \n0,U,root,viejo,abc\n2,U,equipo,ana,$v1$sal$hash\n"
+
+	entries, err := parseUsers(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users := &UsersFile{entries: entries}
+
+	if got := len(users.Groups()); got != 2 {
+		t.Errorf("len(Groups()) = %d, want 2", got)
+	}
+
+	// Los usuarios con UID 0 están eliminados
+	if got := len(users.Users()); got != 2 {
+		t.Errorf("len(Users()) = %d, want 2", got)
+	}
+
+	group := users.FindGroup("EQUIPO")
+	if group == nil || group.GID != 2 || group.Name != "equipo" {
+		t.Errorf("FindGroup(%q) = %+v, want GID 2 equipo", "EQUIPO", group)
+	}
+
+	user := users.FindUser("Ana")
+	if user == nil || user.UID != 2 || user.Groups[0] != "equipo" || user.Password != "$v1$sal$hash" {
+		t.Errorf("FindUser(%q) = %+v", "Ana", user)
+	}
+
+	if users.FindUser("viejo") != nil {
+		t.Errorf("FindUser encontró un usuario eliminado")
+	}
+}
+
+func TestParseUsersMalformed(t *testing.T) {
+	lines := []string{
+		"1,G",
+		"x,G,root",
+		"-1,G,root",
+		"1,G,",
+		"1,G,root,extra",
+		"1,X,root",
+		"1,U,root,root",
+		"1,U,root,,123",
+		"1,U,root,root,123,extra",
+	}
+
+	for _, line := range lines {
+		_, err := parseUsers("1,G,root\n" + line + "\n")
+		if err == nil {
+			t.Errorf("parseUsers aceptó la línea %q", line)
+			continue
+		}
+		if !strings.Contains(err.Error(), "línea 2") {
+			t.Errorf("parseUsers(%q) = %v, want error en la línea 2", line, err)
+		}
+	}
+}
+
+// String vuelve a escribir las líneas en el orden del archivo, incluidas las eliminadas
+func TestUsersStringRoundTrip(t *testing.T) {
+	content := "1,G,root\n1,U,root,root,123\n0,G,viejo\n2,G,equipo\n0,U,equipo,borrado,x\n2,U,equipo,ana,abc\n"
+
+	entries, err := parseUsers(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users := &UsersFile{entries: entries}
+	if got := users.String(); got != content {
+		t.Errorf("String() = %q, want %q", got, content)
+	}
+}
+
+func TestUsersFileSave(t *testing.T) {
+	fs := newTestFileSystem(t, 512*1024)
+	if err := fs.CreateUsersFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := fs.LoadUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := users.Authenticate("root", "123"); err != nil {
+		t.Fatalf("root no entra con la contraseña predeterminada: %v", err)
+	}
+
+	if _, err := users.AddGroup("equipo"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Suficientes usuarios para que el archivo ocupe varios bloques
+	for _, name := range []string{"ana", "beto", "carla", "dario"} {
+		if _, err := users.AddUser(name, "equipo", "$v1$"+strings.Repeat("s", 22)+"$"+strings.Repeat("h", 43)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := users.RemoveUser("beto"); err != nil {
+		t.Fatal(err)
+	}
+
+	freeBefore := fs.Sb.FreeBlocksCount
+	want := users.String()
+	wantBlocks := (int32(len(want)) + fs.Sb.BlockSize - 1) / fs.Sb.BlockSize
+	oldBlocks, err := fs.GetDataBlocks(users.inode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := users.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := fs.LoadUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := reloaded.String(); got != want {
+		t.Errorf("después de Save() = %q, want %q", got, want)
+	}
+
+	// Los bloques anteriores se liberan después de escribir los nuevos
+	if used := freeBefore - fs.Sb.FreeBlocksCount; used != wantBlocks-int32(len(oldBlocks)) {
+		t.Errorf("Save() usó %d bloques netos, want %d", used, wantBlocks-int32(len(oldBlocks)))
+	}
+
+	if user := reloaded.FindUser("dario"); user == nil || user.UID != 5 {
+		t.Errorf("FindUser(%q) = %+v, want UID 5", "dario", user)
+	}
+
+	if reloaded.FindUser("beto") != nil {
+		t.Errorf("beto sigue activo después de RemoveUser")
+	}
+}
+
+func TestUsersFileValidation(t *testing.T) {
+	entries, err := parseUsers("1,G,root\n1,U,root,root,123\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := &UsersFile{entries: entries}
+
+	for _, name := range []string{"", "a,b", "a;b", "a b"} {
+		if _, err := users.AddGroup(name); err == nil {
+			t.Errorf("AddGroup(%q) no devolvió error", name)
+		}
+		if _, err := users.AddUser(name, "root", "x"); err == nil {
+			t.Errorf("AddUser(%q) no devolvió error", name)
+		}
+	}
+
+	if _, err := users.AddGroup("ROOT"); err == nil {
+		t.Errorf("AddGroup aceptó un grupo que ya existe con otras mayúsculas")
+	}
+
+	if _, err := users.AddUser("Root", "root", "x"); err == nil {
+		t.Errorf("AddUser aceptó un usuario que ya existe con otras mayúsculas")
+	}
+
+	if _, err := users.AddUser("ana", "noexiste", "x"); err == nil {
+		t.Errorf("AddUser aceptó un grupo que no existe")
+	}
+
+	if _, _, err := users.Authenticate("root", "1234"); err == nil {
+		t.Errorf("Authenticate aceptó una contraseña incorrecta")
+	}
+}
+
+func newTestUsers(t *testing.T, content string) *UsersFile {
+	t.Helper()
+
+	entries, err := parseUsers(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &UsersFile{entries: entries}
+}
+
+func TestParseUsersMultipleGroups(t *testing.T) {
+	users := newTestUsers(t, "1,G,root\n2,G,dev\n3,G,qa\n2,U,dev;qa;root,ana,x\n")
+
+	user := users.FindUser("ana")
+	if user == nil || !slices.Equal(user.Groups, []string{"dev", "qa", "root"}) {
+		t.Fatalf("FindUser(%q) = %+v, want grupos dev;qa;root", "ana", user)
+	}
+
+	if got, want := users.UserGroupIDs(user), []int32{2, 3, 1}; !slices.Equal(got, want) {
+		t.Errorf("UserGroupIDs() = %v, want %v", got, want)
+	}
+
+	if !user.InGroup("QA") || user.InGroup("ops") {
+		t.Errorf("InGroup no coincide con los grupos %v", user.Groups)
+	}
+
+	_, err := parseUsers("1,G,root\n2,U,root;;dev,ana,x\n")
+	if err == nil {
+		t.Errorf("parseUsers aceptó un grupo vacío en la lista")
+	}
+}
+
+func TestUsersGroupMembership(t *testing.T) {
+	users := newTestUsers(t, "1,G,root\n2,G,dev\n3,G,qa\n4,G,ops\n2,U,dev,ana,x\n")
+
+	if err := users.AddUserToGroup("ana", "QA"); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.AddUserToGroup("ana", "ops"); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.AddUserToGroup("ana", "qa"); err == nil {
+		t.Errorf("AddUserToGroup aceptó un grupo al que ya pertenece")
+	}
+	if err := users.AddUserToGroup("ana", "dev"); err == nil {
+		t.Errorf("AddUserToGroup aceptó el grupo principal")
+	}
+	if err := users.AddUserToGroup("ana", "noexiste"); err == nil {
+		t.Errorf("AddUserToGroup aceptó un grupo que no existe")
+	}
+
+	if got, want := users.FindUser("ana").Groups, []string{"dev", "qa", "ops"}; !slices.Equal(got, want) {
+		t.Fatalf("grupos después de AddUserToGroup = %v, want %v", got, want)
+	}
+
+	if err := users.RemoveUserFromGroup("ana", "dev"); err == nil {
+		t.Errorf("RemoveUserFromGroup quitó el grupo principal")
+	}
+	if err := users.RemoveUserFromGroup("ana", "root"); err == nil {
+		t.Errorf("RemoveUserFromGroup aceptó un grupo al que no pertenece")
+	}
+	if err := users.RemoveUserFromGroup("ana", "QA"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := users.FindUser("ana").Groups, []string{"dev", "ops"}; !slices.Equal(got, want) {
+		t.Errorf("grupos después de RemoveUserFromGroup = %v, want %v", got, want)
+	}
+
+	if got, want := users.String(), "1,G,root\n2,G,dev\n3,G,qa\n4,G,ops\n2,U,dev;ops,ana,x\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// El nuevo grupo principal deja de figurar entre los secundarios
+func TestUsersSetUserGroup(t *testing.T) {
+	users := newTestUsers(t, "1,G,root\n2,G,dev\n3,G,qa\n2,U,dev;qa,ana,x\n")
+
+	if err := users.SetUserGroup("ana", "qa"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := users.FindUser("ana").Groups, []string{"qa"}; !slices.Equal(got, want) {
+		t.Errorf("grupos después de SetUserGroup = %v, want %v", got, want)
+	}
+
+	if err := users.SetUserGroup("ana", "noexiste"); err == nil {
+		t.Errorf("SetUserGroup aceptó un grupo que no existe")
+	}
+}
+
+// Al eliminar un grupo sale de los secundarios, pero el principal se conserva
+func TestUsersRemoveGroup(t *testing.T) {
+	users := newTestUsers(t, "1,G,root\n2,G,dev\n3,G,qa\n2,U,dev;qa,ana,x\n3,U,dev;qa,beto,y\n")
+
+	if err := users.RemoveGroup("qa"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"ana", "beto"} {
+		user := users.FindUser(name)
+		if got, want := user.Groups, []string{"dev"}; !slices.Equal(got, want) {
+			t.Errorf("grupos de %s = %v, want %v", name, got, want)
+		}
+
+		// El grupo eliminado ya no aporta su GID
+		if got, want := users.UserGroupIDs(user), []int32{2}; !slices.Equal(got, want) {
+			t.Errorf("UserGroupIDs(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	if got, want := users.String(), "1,G,root\n2,G,dev\n0,G,qa\n2,U,dev,ana,x\n3,U,dev,beto,y\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestUsersRemovePrimaryGroup(t *testing.T) {
+	const content = "1,G,root\n2,G,dev\n3,G,qa\n2,U,qa;dev,ana,x\n3,U,dev;qa,beto,y\n4,U,qa,caro,z\n"
+	users := newTestUsers(t, content)
+
+	err := users.RemoveGroup("qa")
+	if err == nil {
+		t.Fatal("RemoveGroup(qa) no devolvió error con usuarios que lo tienen como principal")
+	}
+	if !strings.Contains(err.Error(), "ana, caro") {
+		t.Errorf("el error %q no lista a ana y caro", err)
+	}
+
+	// No se modifica nada
+	if got := users.String(); got != content {
+		t.Errorf("String() = %q, want %q", got, content)
+	}
+}
+
+func TestUsersFileSaveMultipleGroups(t *testing.T) {
+	fs := newTestFileSystem(t, 512*1024)
+	if err := fs.CreateUsersFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := fs.LoadUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"dev", "qa"} {
+		if _, err := users.AddGroup(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := users.AddUser("ana", "dev", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.AddUserToGroup("ana", "qa"); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.AddUserToGroup("ana", "root"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := users.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := fs.LoadUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(reloaded.String(), "\n2,U,dev;qa;root,ana,x\n") {
+		t.Errorf("users.txt guardado = %q, want línea 2,U,dev;qa;root,ana,x", reloaded.String())
+	}
+
+	if got, want := reloaded.UserGroupIDs(reloaded.FindUser("ana")), []int32{2, 3, 1}; !slices.Equal(got, want) {
+		t.Errorf("UserGroupIDs() = %v, want %v", got, want)
+	}
+}
@@ -96,3 +96,18 @@ func DeleteFile(name string) error {
 	}
 	return nil
 }
+
+func WriteZeros(file *os.File, position int64, size int64) error {
+	zeroBuffer := make([]byte, 1024)
+
+	for size > 0 {
+		chunk := min(size, int64(len(zeroBuffer)))
+		if err := WriteBytes(file, zeroBuffer[:chunk], position); err != nil {
+			return err
+		}
+		position += chunk
+		size -= chunk
+	}
+
+	return nil
+}